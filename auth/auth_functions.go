@@ -7,13 +7,10 @@ import (
 	"strings"
 	"time"
 
-	homedir "github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
 	"github.com/splicemachine/splicectl/common"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 
 	// This is the way
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -60,7 +57,7 @@ func (i *Info) GetSession() common.SessionData {
 
 // RetrieveTokenBearer - fetch the token from the K8s secret
 func (i *Info) RetrieveTokenBearer() bool {
-	cfg, err := restConfig()
+	cfg, err := common.RestConfig()
 	if err != nil {
 		logrus.WithError(err).Fatal("could not get config")
 		os.Exit(1)
@@ -72,7 +69,7 @@ func (i *Info) RetrieveTokenBearer() bool {
 		os.Exit(1)
 	}
 
-	secretResult, err := client.CoreV1().Secrets("splice-system").Get(context.TODO(), "splicectl-api-tokens", v1.GetOptions{})
+	secretResult, err := client.CoreV1().Secrets(common.Target.Namespace).Get(context.TODO(), "splicectl-api-tokens", v1.GetOptions{})
 	if err != nil {
 		logrus.WithError(err).Fatal("could not read from secret: splicectl-api-tokens")
 		os.Exit(1)
@@ -101,38 +98,3 @@ func (i *Info) CheckTokenValidity() bool {
 	}
 	return i.RetrieveTokenBearer()
 }
-
-func restConfig() (*rest.Config, error) {
-	// We aren't likely to run this INSIDE the K8s cluster, this routine
-	// simply picks up the config from the file system of a running POD.
-	// kubeCfg, err := rest.InClusterConfig()
-	var kubeCfg *rest.Config
-	var err error
-
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			logrus.Info("No KUBECONFIG ENV")
-			return nil, err
-		}
-	} else {
-		// ENV KUBECONFIG not set, check for ~/.kube/config
-		home, err := homedir.Dir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		kubeFile := fmt.Sprintf("%s/%s", home, ".kube/config")
-		if _, err := os.Stat(kubeFile); err != nil {
-			if os.IsNotExist(err) {
-				if os.Args[1] != "version" {
-					logrus.Info("Could not locate the KUBECONFIG file, normally ~/.kube/config")
-					os.Exit(1)
-				}
-				return nil, nil
-			}
-		}
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeFile)
-	}
-	return kubeCfg, nil
-}
@@ -0,0 +1,161 @@
+// Package client is a typed Go client for the splicectl API server, it is
+// what the splicectl commands are built on and can be embedded in other
+// automation.
+//
+//	cl := client.New("https://splicectl.example.com", client.WithCredentials(creds))
+//	dbs, err := cl.ListDatabases(context.TODO())
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Credentials - supplies the auth headers sent with each request,
+// auth.Client satisfies this interface.
+type Credentials interface {
+	GetTokenBearer() string
+	GetSessionID() string
+}
+
+// Client - a connection to one splicectl API server
+type Client struct {
+	server   string
+	caBundle string
+	creds    Credentials
+	http     *http.Client
+	observe  ResponseHook
+}
+
+// ResponseHook - called with each successful response to a request that
+// changes something on the server, anything but GET and LIST
+type ResponseHook func(method, path string, query url.Values, body []byte)
+
+// Option - configures a Client
+type Option func(*Client)
+
+// WithCredentials - authenticate requests with the token bearer and session
+func WithCredentials(creds Credentials) Option {
+	return func(c *Client) {
+		c.creds = creds
+	}
+}
+
+// WithCABundle - PEM encoded certificate(s) used to validate the server
+func WithCABundle(pem string) Option {
+	return func(c *Client) {
+		c.caBundle = pem
+	}
+}
+
+// WithHTTPClient - use a specific http.Client for every request
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.http = hc
+	}
+}
+
+// WithResponseHook - observe the responses of requests that change state,
+// splicectl uses this for its audit log and to drop what it has cached
+func WithResponseHook(hook ResponseHook) Option {
+	return func(c *Client) {
+		c.observe = hook
+	}
+}
+
+// New - creates a client for the API server at server, in the form of
+// http(s)://host.domain.name[:port]
+func New(server string, opts ...Option) *Client {
+	c := &Client{server: strings.TrimSuffix(server, "/")}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Server - the API server this client talks to
+func (c *Client) Server() string {
+	return c.server
+}
+
+// ErrInvalidCABundle - the CA bundle did not contain any usable certificates
+var ErrInvalidCABundle = errors.New("failed to parse CA bundle")
+
+func (c *Client) resty() (*resty.Client, error) {
+	rc := resty.New()
+	if c.http != nil {
+		rc = resty.NewWithClient(c.http)
+	}
+	if len(c.caBundle) > 0 {
+		roots := x509.NewCertPool()
+		if ok := roots.AppendCertsFromPEM([]byte(c.caBundle)); !ok {
+			return nil, ErrInvalidCABundle
+		}
+		rc.SetTLSClientConfig(&tls.Config{RootCAs: roots})
+	}
+	return rc, nil
+}
+
+// do - performs a request against the API, any response outside of the 2xx
+// range is returned as an *APIError and transport failures as a
+// *NetworkError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}) ([]byte, error) {
+	rc, err := c.resty()
+	if err != nil {
+		return nil, err
+	}
+	req := rc.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Accept", "application/json")
+	if c.creds != nil {
+		req.SetHeader("X-Token-Bearer", c.creds.GetTokenBearer()).
+			SetHeader("X-Token-Session", c.creds.GetSessionID())
+	}
+	if query != nil {
+		req.SetQueryParamsFromValues(query)
+	}
+	if body != nil {
+		req.SetBody(body)
+	}
+
+	resp, err := req.Execute(method, fmt.Sprintf("%s/%s", c.server, path))
+	if err != nil {
+		return nil, &NetworkError{Method: method, Path: path, Err: err}
+	}
+	if resp.IsError() || resp.StatusCode() >= http.StatusMultipleChoices {
+		return nil, newAPIError(method, path, resp)
+	}
+	if c.observe != nil && method != http.MethodGet && method != "LIST" {
+		c.observe(method, path, query, resp.Body())
+	}
+	return resp.Body(), nil
+}
+
+// getJSON - performs a request and decodes the response body into out
+func (c *Client) getJSON(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) error {
+	_, err := c.getJSONRaw(ctx, method, path, query, body, out)
+	return err
+}
+
+// getJSONRaw - performs a request and decodes the response body into out,
+// the body is also returned as the server sent it
+func (c *Client) getJSONRaw(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}) (json.RawMessage, error) {
+	data, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("%v; could not decode the response from %s", err, path)
+	}
+	return data, nil
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testCreds struct{}
+
+func (testCreds) GetTokenBearer() string { return "bearer" }
+func (testCreds) GetSessionID() string   { return "session" }
+
+// newTestClient - a client talking to an httptest server running handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(srv.URL, WithCredentials(testCreds{}))
+}
+
+func TestListDatabases(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "LIST" || r.URL.Path != "/splicectl/v1/splicedb/splicedatabase" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Token-Bearer") != "bearer" || r.Header.Get("X-Token-Session") != "session" {
+			t.Errorf("auth headers were not sent, got %v", r.Header)
+		}
+		w.Write([]byte(`{"clusters":[{"dcosAppId":"splicedb","status":"Active"}]}`))
+	})
+
+	dbList, err := cl.ListDatabases(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dbList.Clusters) != 1 || dbList.Clusters[0].DcosAppId != "splicedb" {
+		t.Fatalf("unexpected database list: %+v", dbList)
+	}
+}
+
+func TestGetDatabaseCR(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/splicectl/v1/vault/databasecr" || q.Get("database-name") != "splicedb" || q.Get("version") != "3" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"data":{"metadata":{"name":"splicedb"}}}`))
+	})
+
+	cr, err := cl.GetDatabaseCR(context.TODO(), "splicedb", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cr.Data["metadata"]; !ok {
+		t.Fatalf("expected CR with metadata, got %+v", cr.Data)
+	}
+}
+
+func TestGetVaultRaw(t *testing.T) {
+	body := `{"data":{"metadata":{"name":"splicedb"}},"version":3,"created":"2021-06-01"}`
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+
+	cr := map[string]interface{}{}
+	raw, err := cl.GetVaultRaw(context.TODO(), DatabaseCR("splicedb"), 0, &cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != body {
+		t.Errorf("the body should be kept verbatim, got %s", raw)
+	}
+	if _, ok := cr["data"]; !ok {
+		t.Errorf("the body was not decoded, got %v", cr)
+	}
+}
+
+func TestRawVariants(t *testing.T) {
+	// fields the objects don't declare and the order of keys are kept
+	body := `{"zeta":1,"success":true,"clusters":[],"versions":{}}`
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	ctx := context.TODO()
+	raws := map[string]func() (json.RawMessage, error){
+		"ListDatabasesRaw": func() (json.RawMessage, error) { _, raw, err := cl.ListDatabasesRaw(ctx); return raw, err },
+		"ListAccountsRaw":  func() (json.RawMessage, error) { _, raw, err := cl.ListAccountsRaw(ctx); return raw, err },
+		"RestartRaw":       func() (json.RawMessage, error) { _, raw, err := cl.RestartRaw(ctx, "db", false); return raw, err },
+		"PauseRaw":         func() (json.RawMessage, error) { _, raw, err := cl.PauseRaw(ctx, "db", ""); return raw, err },
+		"RollbackRaw": func() (json.RawMessage, error) {
+			_, raw, err := cl.RollbackRaw(ctx, SystemSettings(), 2)
+			return raw, err
+		},
+		"ApplyVaultDocumentRaw": func() (json.RawMessage, error) {
+			_, raw, err := cl.ApplyVaultDocumentRaw(ctx, SystemSettings(), []byte(`{}`))
+			return raw, err
+		},
+	}
+	for name, fetch := range raws {
+		raw, err := fetch()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if string(raw) != body {
+			t.Errorf("%s = %s, want the body verbatim", name, raw)
+		}
+	}
+}
+
+func TestApplyVaultKey(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Query().Get("keypath") != "services/test" || string(body) != `{"key":"value"}` {
+			t.Errorf("unexpected request %s %s: %s", r.Method, r.URL, body)
+		}
+		w.Write([]byte(`{"version":4,"created_time":"now"}`))
+	})
+
+	vv, err := cl.ApplyVaultKey(context.TODO(), "services/test", []byte(`{"key":"value"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vv.Version != 4 {
+		t.Fatalf("expected version 4, got %d", vv.Version)
+	}
+}
+
+func TestListVersions(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/splicectl/v1/vault/systemsettingsversions" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"2":{"created_time":"b"},"1":{"created_time":"a"},"10":{"created_time":"c"}}`))
+	})
+
+	vl, err := cl.ListVersions(context.TODO(), SystemSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int{1, 2, 10} {
+		if vl.Versions[i].Version != want {
+			t.Fatalf("expected version %d at %d, got %+v", want, i, vl.Versions)
+		}
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such workspace", http.StatusNotFound)
+	})
+
+	_, err := cl.Pause(context.TODO(), "missing", "")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Body != "no such workspace" {
+		t.Fatalf("unexpected error contents: %+v", apiErr)
+	}
+}
+
+func TestAPIErrorPayload(t *testing.T) {
+	cl := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"version mismatch"}`))
+	})
+
+	_, err := cl.ApplyDefaultCR(context.TODO(), []byte(`{}`))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %v", err)
+	}
+	if apiErr.Message != "version mismatch" || apiErr.RequestID != "req-123" {
+		t.Fatalf("payload was not decoded: %+v", apiErr)
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// workspaceAction - request body for pause and resume
+type workspaceAction struct {
+	AppID   string `json:"appId"`
+	Message string `json:"message"`
+}
+
+// ServerVersion - the version of the API server, no credentials are needed
+func (c *Client) ServerVersion(ctx context.Context) (*objects.BaseVersion, error) {
+	version := &objects.BaseVersion{}
+	if err := c.getJSON(ctx, http.MethodGet, "splicectl", nil, nil, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// Auth - requests a new auth session, no credentials are needed
+func (c *Client) Auth(ctx context.Context) (*common.SessionData, error) {
+	sess := &common.SessionData{}
+	if err := c.getJSON(ctx, http.MethodGet, "splicectl/v1/auth", nil, nil, sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// ListAccounts - the Cloud Manager accounts
+func (c *Client) ListAccounts(ctx context.Context) (*objects.AccountList, error) {
+	accounts, _, err := c.ListAccountsRaw(ctx)
+	return accounts, err
+}
+
+// ListAccountsRaw - ListAccounts and the body as the server sent it
+func (c *Client) ListAccountsRaw(ctx context.Context) (*objects.AccountList, json.RawMessage, error) {
+	accounts := &objects.AccountList{}
+	raw, err := c.getJSONRaw(ctx, http.MethodGet, "splicectl/v1/cm/accounts", nil, nil, accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accounts, raw, nil
+}
+
+// ListDatabases - the workspaces known to Cloud Manager
+func (c *Client) ListDatabases(ctx context.Context) (*objects.DatabaseList, error) {
+	dbList, _, err := c.ListDatabasesRaw(ctx)
+	return dbList, err
+}
+
+// ListDatabasesRaw - ListDatabases and the body as the server sent it
+func (c *Client) ListDatabasesRaw(ctx context.Context) (*objects.DatabaseList, json.RawMessage, error) {
+	dbList := &objects.DatabaseList{}
+	raw, err := c.getJSONRaw(ctx, "LIST", "splicectl/v1/splicedb/splicedatabase", nil, nil, dbList)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dbList, raw, nil
+}
+
+// CreateDatabase - asks Cloud Manager to create a new workspace
+func (c *Client) CreateDatabase(ctx context.Context, req *objects.DatabaseRequest) (*objects.ActionStatus, error) {
+	status := &objects.ActionStatus{}
+	if err := c.getJSON(ctx, http.MethodPost, "splicectl/v1/splicedb/splicedatabase", nil, req, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// DeleteDatabase - deletes the workspace with the given cluster id
+func (c *Client) DeleteDatabase(ctx context.Context, clusterID string) (*objects.ActionStatus, error) {
+	status := &objects.ActionStatus{}
+	query := url.Values{"database-name": {clusterID}}
+	if err := c.getJSON(ctx, http.MethodDelete, "splicectl/v1/splicedb/splicedatabasedelete", query, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Pause - pauses an active workspace, message is added to the workspace log
+func (c *Client) Pause(ctx context.Context, database, message string) (*objects.ActionStatus, error) {
+	status, _, err := c.PauseRaw(ctx, database, message)
+	return status, err
+}
+
+// PauseRaw - Pause and the body as the server sent it
+func (c *Client) PauseRaw(ctx context.Context, database, message string) (*objects.ActionStatus, json.RawMessage, error) {
+	body := workspaceAction{AppID: database, Message: message}
+	return c.action(ctx, "splicectl/v1/splicedb/splicedatabasepause", nil, body)
+}
+
+// Resume - resumes a paused workspace, message is added to the workspace log
+func (c *Client) Resume(ctx context.Context, database, message string) (*objects.ActionStatus, error) {
+	status, _, err := c.ResumeRaw(ctx, database, message)
+	return status, err
+}
+
+// ResumeRaw - Resume and the body as the server sent it
+func (c *Client) ResumeRaw(ctx context.Context, database, message string) (*objects.ActionStatus, json.RawMessage, error) {
+	body := workspaceAction{AppID: database, Message: message}
+	return c.action(ctx, "splicectl/v1/splicedb/splicedatabaseresume", nil, body)
+}
+
+// Restart - restarts a workspace
+func (c *Client) Restart(ctx context.Context, database string, force bool) (*objects.ActionStatus, error) {
+	status, _, err := c.RestartRaw(ctx, database, force)
+	return status, err
+}
+
+// RestartRaw - Restart and the body as the server sent it
+func (c *Client) RestartRaw(ctx context.Context, database string, force bool) (*objects.ActionStatus, json.RawMessage, error) {
+	query := url.Values{
+		"database-name": {database},
+		"force":         {strconv.FormatBool(force)},
+	}
+	return c.action(ctx, "splicectl/v1/splicedb/splicedatabaserestart", query, nil)
+}
+
+// action - posts a workspace action, its status and the body as it was sent
+func (c *Client) action(ctx context.Context, path string, query url.Values, body interface{}) (*objects.ActionStatus, json.RawMessage, error) {
+	status := &objects.ActionStatus{}
+	raw, err := c.getJSONRaw(ctx, http.MethodPost, path, query, body, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, raw, nil
+}
+
+// GetDatabaseStatus - the status the API server reports for a workspace
+func (c *Client) GetDatabaseStatus(ctx context.Context, database string) (objects.DatabaseStatus, error) {
+	status := objects.DatabaseStatus{}
+	query := url.Values{"database-name": {database}}
+	if err := c.getJSON(ctx, http.MethodGet, "splicectl/v1/splicedb/splicedatabasestatus", query, nil, &status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// GetImageTags - the image tags for a component of a workspace
+func (c *Client) GetImageTags(ctx context.Context, database, component string) (*objects.ImageTagList, error) {
+	tags, _, err := c.GetImageTagsRaw(ctx, database, component)
+	return tags, err
+}
+
+// GetImageTagsRaw - GetImageTags and the body as the server sent it
+func (c *Client) GetImageTagsRaw(ctx context.Context, database, component string) (*objects.ImageTagList, json.RawMessage, error) {
+	tags := make([]objects.ImageTag, 0)
+	query := url.Values{
+		"component-name": {component},
+		"database-name":  {database},
+	}
+	raw, err := c.getJSONRaw(ctx, http.MethodGet, "splicectl/v1/splicedb/imagetag", query, nil, &tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &objects.ImageTagList{ImageTags: tags}, raw, nil
+}
+
+// SetImageTag - sets the image tag for a component of a workspace
+func (c *Client) SetImageTag(ctx context.Context, database, component, tag string) (*objects.ActionStatus, error) {
+	status, _, err := c.SetImageTagRaw(ctx, database, component, tag)
+	return status, err
+}
+
+// SetImageTagRaw - SetImageTag and the body as the server sent it
+func (c *Client) SetImageTagRaw(ctx context.Context, database, component, tag string) (*objects.ActionStatus, json.RawMessage, error) {
+	query := url.Values{
+		"component-name": {component},
+		"database-name":  {database},
+		"tag":            {tag},
+	}
+	return c.action(ctx, "splicectl/v1/splicedb/imagetag", query, nil)
+}
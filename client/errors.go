@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// APIError - a response from the API server outside of the 2xx range
+type APIError struct {
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"status"`
+	Message    string `json:"message"`
+	RequestID  string `json:"requestId,omitempty"`
+	Body       string `json:"-"`
+}
+
+// errorPayload - the fields the API server may use to describe an error
+type errorPayload struct {
+	Message      string `json:"message"`
+	Error        string `json:"error"`
+	RequestID    string `json:"requestId"`
+	RequestIDAlt string `json:"request_id"`
+}
+
+func newAPIError(method, path string, resp *resty.Response) *APIError {
+	e := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: resp.StatusCode(),
+		Body:       strings.TrimSpace(string(resp.Body())),
+		RequestID:  resp.Header().Get("X-Request-Id"),
+	}
+
+	// The server answers with a JSON payload most of the time, fall back to
+	// the body itself, and then the status text, for anything else.
+	var payload errorPayload
+	if err := json.Unmarshal(resp.Body(), &payload); err == nil {
+		e.Message = payload.Message
+		if len(e.Message) == 0 {
+			e.Message = payload.Error
+		}
+		if len(e.RequestID) == 0 {
+			e.RequestID = payload.RequestID
+		}
+		if len(e.RequestID) == 0 {
+			e.RequestID = payload.RequestIDAlt
+		}
+	} else {
+		e.Message = e.Body
+	}
+	if len(e.Message) == 0 {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: server responded with status %d: %s", e.Method, e.Path, e.StatusCode, e.Message)
+	if len(e.RequestID) > 0 {
+		msg = fmt.Sprintf("%s (request id %s)", msg, e.RequestID)
+	}
+	return msg
+}
+
+// NetworkError - the request never got a response from the API server
+type NetworkError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.Path, e.Err)
+}
+
+// Unwrap - the underlying transport error
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
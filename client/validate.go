@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const dataKey = "data"
+
+var (
+	// ErrNoTopLevelData - a CR without the 'data' element
+	ErrNoTopLevelData = errors.New("Default CR did not contain top level 'data' element that is required")
+	// ErrDataIsWrongType - a CR whose 'data' element is not an object
+	ErrDataIsWrongType = errors.New("data element in Default CR is not an object, but should be")
+	// ErrDoubleNestedData - a CR with 'data' inside of 'data'
+	ErrDoubleNestedData = errors.New("Default CR appears to contain a second level 'data' element, your Default CR appears to be double nested")
+	// ErrNotAnObject - a document that is not a JSON object
+	ErrNotAnObject = errors.New("the document must be an object")
+)
+
+// ValidateDefaultCR - validate that the data representing default-cr contains a top
+// level field named 'data'.
+func ValidateDefaultCR(defaultCR []byte) (interface{}, error) {
+	// get map representation of Default CR
+	crMap := make(map[string]interface{})
+	if err := json.Unmarshal(defaultCR, &crMap); err != nil {
+		return nil, err
+	}
+
+	// get the data element of the Default CR
+	crData, ok := crMap[dataKey]
+	if !ok {
+		return crMap, ErrNoTopLevelData
+	}
+
+	// verify that the data element is a map
+	crDataMap, ok := crData.(map[string]interface{})
+	if !ok {
+		return crMap, ErrDataIsWrongType
+	}
+
+	// verify that there is not a data element in the top level data element,
+	// would imply double nesting of Default CR
+	if _, ok := crDataMap[dataKey]; ok {
+		return crData, ErrDoubleNestedData
+	}
+
+	return crMap, nil
+}
+
+// ValidateVaultDocument - checks doc, which must be JSON, is in the shape the
+// kind of r expects before it is stored.  CRs are checked the way
+// ValidateDefaultCR does, everything else must be an object.
+func ValidateVaultDocument(r VaultResource, doc []byte) error {
+	switch r.Kind {
+	case KindDefaultCR, KindDatabaseCR:
+		_, err := ValidateDefaultCR(doc)
+		return err
+	}
+	var data interface{}
+	if err := json.Unmarshal(doc, &data); err != nil {
+		return err
+	}
+	if _, ok := data.(map[string]interface{}); !ok {
+		return fmt.Errorf("%s: %w", r, ErrNotAnObject)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+// VaultKind - the kinds of vault backed documents the API server manages
+type VaultKind string
+
+const (
+	KindDefaultCR      VaultKind = "default-cr"
+	KindDatabaseCR     VaultKind = "database-cr"
+	KindSystemSettings VaultKind = "system-settings"
+	KindCMSettings     VaultKind = "cm-settings"
+	KindVaultKey       VaultKind = "vault-key"
+)
+
+// vaultEndpoints - the API names each kind is exposed under, the get/apply
+// endpoint is vault/<name>, with vault/<name>versions and
+// vault/rollback<name> alongside.
+var vaultEndpoints = map[VaultKind]string{
+	KindDefaultCR:      "defaultcr",
+	KindDatabaseCR:     "databasecr",
+	KindSystemSettings: "systemsettings",
+	KindCMSettings:     "cmsettings",
+	KindVaultKey:       "vaultkey",
+}
+
+// VaultResource - identifies a single vault backed document
+type VaultResource struct {
+	Kind      VaultKind
+	Database  string
+	Component string
+	KeyPath   string
+}
+
+// DefaultCR - the default CR used for new workspaces
+func DefaultCR() VaultResource {
+	return VaultResource{Kind: KindDefaultCR}
+}
+
+// DatabaseCR - the CR of a workspace
+func DatabaseCR(database string) VaultResource {
+	return VaultResource{Kind: KindDatabaseCR, Database: database}
+}
+
+// SystemSettings - the settings the cluster was installed with
+func SystemSettings() VaultResource {
+	return VaultResource{Kind: KindSystemSettings}
+}
+
+// CMSettings - the cloud manager settings of a component, ui or api
+func CMSettings(component string) VaultResource {
+	return VaultResource{Kind: KindCMSettings, Component: component}
+}
+
+// VaultKey - an arbitrary vault key path
+func VaultKey(keyPath string) VaultResource {
+	return VaultResource{Kind: KindVaultKey, KeyPath: keyPath}
+}
+
+// String - a readable name for the resource, used in messages
+func (r VaultResource) String() string {
+	switch r.Kind {
+	case KindDatabaseCR:
+		return fmt.Sprintf("%s %s", r.Kind, r.Database)
+	case KindCMSettings:
+		return fmt.Sprintf("%s %s", r.Kind, r.Component)
+	case KindVaultKey:
+		return fmt.Sprintf("%s %s", r.Kind, r.KeyPath)
+	}
+	return string(r.Kind)
+}
+
+func (r VaultResource) endpoint() (string, error) {
+	name, ok := vaultEndpoints[r.Kind]
+	if !ok {
+		return "", fmt.Errorf("unknown vault kind '%s'", r.Kind)
+	}
+	return name, nil
+}
+
+func (r VaultResource) query() url.Values {
+	query := url.Values{}
+	switch r.Kind {
+	case KindDatabaseCR:
+		query.Set("database-name", r.Database)
+	case KindCMSettings:
+		query.Set("component", r.Component)
+	case KindVaultKey:
+		query.Set("keypath", r.KeyPath)
+	}
+	return query
+}
+
+// GetVaultDocument - the document stored for the resource as JSON, version 0
+// is the latest version.
+func (c *Client) GetVaultDocument(ctx context.Context, r VaultResource, version int) (json.RawMessage, error) {
+	name, err := r.endpoint()
+	if err != nil {
+		return nil, err
+	}
+	query := r.query()
+	query.Set("version", strconv.Itoa(version))
+	return c.do(ctx, http.MethodGet, "splicectl/v1/vault/"+name, query, nil)
+}
+
+// ApplyVaultDocument - stores doc, which must be JSON, as a new version
+func (c *Client) ApplyVaultDocument(ctx context.Context, r VaultResource, doc []byte) (*objects.VaultVersion, error) {
+	vv, _, err := c.ApplyVaultDocumentRaw(ctx, r, doc)
+	return vv, err
+}
+
+// ApplyVaultDocumentRaw - ApplyVaultDocument and the body as the server
+// sent it
+func (c *Client) ApplyVaultDocumentRaw(ctx context.Context, r VaultResource, doc []byte) (*objects.VaultVersion, json.RawMessage, error) {
+	name, err := r.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	vv := &objects.VaultVersion{}
+	raw, err := c.getJSONRaw(ctx, http.MethodPost, "splicectl/v1/vault/"+name, r.query(), doc, vv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vv, raw, nil
+}
+
+// ListVersions - the vault versions of the resource, oldest first
+func (c *Client) ListVersions(ctx context.Context, r VaultResource) (*objects.VaultVersionList, error) {
+	versions, _, err := c.ListVersionsRaw(ctx, r)
+	return versions, err
+}
+
+// ListVersionsRaw - ListVersions and the body as the server sent it, keyed
+// by version number
+func (c *Client) ListVersionsRaw(ctx context.Context, r VaultResource) (*objects.VaultVersionList, json.RawMessage, error) {
+	name, err := r.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := c.do(ctx, http.MethodGet, "splicectl/v1/vault/"+name+"versions", r.query(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	versions, err := restructureVersions(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions, data, nil
+}
+
+// Rollback - creates a new version of the resource from an older version
+func (c *Client) Rollback(ctx context.Context, r VaultResource, version int) (*objects.VaultVersion, error) {
+	vv, _, err := c.RollbackRaw(ctx, r, version)
+	return vv, err
+}
+
+// RollbackRaw - Rollback and the body as the server sent it
+func (c *Client) RollbackRaw(ctx context.Context, r VaultResource, version int) (*objects.VaultVersion, json.RawMessage, error) {
+	name, err := r.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+	query := r.query()
+	query.Set("version", strconv.Itoa(version))
+	vv := &objects.VaultVersion{}
+	raw, err := c.getJSONRaw(ctx, http.MethodPost, "splicectl/v1/vault/rollback"+name, query, nil, vv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vv, raw, nil
+}
+
+// restructureVersions - Vault Version JSON is not well, needs some help.
+func restructureVersions(in []byte) (*objects.VaultVersionList, error) {
+	// The raw data out of Hashicorp Vault for versions uses JSON keys that
+	// are numeric (Version), rather than "version": <versionnum>, so we
+	// strip out the first level map[string] and re-build the struct with
+	// a Version field and populate it with the retured key.
+	rawData := map[string]objects.VaultVersion{}
+	if err := json.Unmarshal(in, &rawData); err != nil {
+		return nil, err
+	}
+	versionList := make([]objects.VaultVersion, 0, len(rawData))
+	for k, v := range rawData {
+		i, err := strconv.Atoi(k)
+		if err != nil {
+			return nil, fmt.Errorf("%v; unexpected version key '%s'", err, k)
+		}
+		v.Version = i
+		versionList = append(versionList, v)
+	}
+	sort.Slice(versionList, func(i, j int) bool {
+		return versionList[i].Version < versionList[j].Version
+	})
+
+	return &objects.VaultVersionList{Versions: versionList}, nil
+}
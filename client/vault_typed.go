@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+func (c *Client) getVaultInto(ctx context.Context, r VaultResource, version int, out interface{}) error {
+	_, err := c.GetVaultRaw(ctx, r, version, out)
+	return err
+}
+
+// GetVaultRaw - the document stored for the resource exactly as the server
+// sent it, and decoded into out, for -o raw to print it verbatim
+func (c *Client) GetVaultRaw(ctx context.Context, r VaultResource, version int, out interface{}) (json.RawMessage, error) {
+	data, err := c.GetVaultDocument(ctx, r, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetDefaultCR - the default CR, version 0 is the latest
+func (c *Client) GetDefaultCR(ctx context.Context, version int) (map[string]interface{}, error) {
+	cr := map[string]interface{}{}
+	if err := c.getVaultInto(ctx, DefaultCR(), version, &cr); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// GetDatabaseCR - the CR of a workspace, version 0 is the latest
+func (c *Client) GetDatabaseCR(ctx context.Context, database string, version int) (*objects.DatabaseCR, error) {
+	cr := &objects.DatabaseCR{}
+	if err := c.getVaultInto(ctx, DatabaseCR(database), version, cr); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// GetSystemSettings - the system settings, version 0 is the latest
+func (c *Client) GetSystemSettings(ctx context.Context, version int) (*objects.SystemSettings, error) {
+	settings := &objects.SystemSettings{}
+	if err := c.getVaultInto(ctx, SystemSettings(), version, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetCMSettings - the cloud manager settings of a component, version 0 is the latest
+func (c *Client) GetCMSettings(ctx context.Context, component string, version int) (*objects.CMSettings, error) {
+	settings := &objects.CMSettings{}
+	if err := c.getVaultInto(ctx, CMSettings(component), version, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetVaultKey - the data at a vault key path, version 0 is the latest
+func (c *Client) GetVaultKey(ctx context.Context, keyPath string, version int) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if err := c.getVaultInto(ctx, VaultKey(keyPath), version, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ApplyDefaultCR - stores a new version of the default CR
+func (c *Client) ApplyDefaultCR(ctx context.Context, doc []byte) (*objects.VaultVersion, error) {
+	return c.ApplyVaultDocument(ctx, DefaultCR(), doc)
+}
+
+// ApplyDatabaseCR - stores a new version of a workspace CR
+func (c *Client) ApplyDatabaseCR(ctx context.Context, database string, doc []byte) (*objects.VaultVersion, error) {
+	return c.ApplyVaultDocument(ctx, DatabaseCR(database), doc)
+}
+
+// ApplySystemSettings - stores a new version of the system settings
+func (c *Client) ApplySystemSettings(ctx context.Context, doc []byte) (*objects.VaultVersion, error) {
+	return c.ApplyVaultDocument(ctx, SystemSettings(), doc)
+}
+
+// ApplyCMSettings - stores a new version of the cloud manager settings of a component
+func (c *Client) ApplyCMSettings(ctx context.Context, component string, doc []byte) (*objects.VaultVersion, error) {
+	return c.ApplyVaultDocument(ctx, CMSettings(component), doc)
+}
+
+// ApplyVaultKey - stores a new version of the data at a vault key path
+func (c *Client) ApplyVaultKey(ctx context.Context, keyPath string, doc []byte) (*objects.VaultVersion, error) {
+	return c.ApplyVaultDocument(ctx, VaultKey(keyPath), doc)
+}
+
+// RollbackDefaultCR - restores an older version of the default CR
+func (c *Client) RollbackDefaultCR(ctx context.Context, version int) (*objects.VaultVersion, error) {
+	return c.Rollback(ctx, DefaultCR(), version)
+}
+
+// RollbackDatabaseCR - restores an older version of a workspace CR
+func (c *Client) RollbackDatabaseCR(ctx context.Context, database string, version int) (*objects.VaultVersion, error) {
+	return c.Rollback(ctx, DatabaseCR(database), version)
+}
+
+// RollbackSystemSettings - restores an older version of the system settings
+func (c *Client) RollbackSystemSettings(ctx context.Context, version int) (*objects.VaultVersion, error) {
+	return c.Rollback(ctx, SystemSettings(), version)
+}
+
+// RollbackCMSettings - restores an older version of the cloud manager settings of a component
+func (c *Client) RollbackCMSettings(ctx context.Context, component string, version int) (*objects.VaultVersion, error) {
+	return c.Rollback(ctx, CMSettings(component), version)
+}
+
+// RollbackVaultKey - restores an older version of the data at a vault key path
+func (c *Client) RollbackVaultKey(ctx context.Context, keyPath string, version int) (*objects.VaultVersion, error) {
+	return c.Rollback(ctx, VaultKey(keyPath), version)
+}
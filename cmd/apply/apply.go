@@ -1,20 +1,61 @@
 package apply
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/splicemachine/splicectl/cmd/config"
 )
 
 var applyCmd = &cobra.Command{
 	Use:   "apply",
-	Args:  cobra.MinimumNArgs(1),
 	Short: "Apply configurations to various resources of the Splice Machine Database Cluster",
 	Long: `EXAMPLES
 	splicectl get system-settings > ~/tmp/system-settings.json
 	# edit the file
 	splicectl apply system-settings --file ~/tmp/system-settings.json
+
+	# reconcile the Workspace manifests in a directory, the plan is shown first
+	splicectl apply -f workspaces/
+	splicectl apply -f workspaces/splicedb.yaml --dry-run
+
+	A Workspace manifest wraps the request used by 'create workspace' with the
+	image tag of each component and whether the workspace should be paused:
+
+	apiVersion: splicectl/v1
+	kind: Workspace
+	metadata:
+	  name: splicedb
+	spec:
+	  accountId: <accountid>
+	  cloudProvider: AWS
+	  clusterPowerOltp: 4
+	  clusterPowerOlap: 4
+	  imageTags:
+	    hbase: master-246
+	  paused: false
+
+	Missing workspaces are created, image tags are set and workspaces are
+	paused or resumed to match.  The image tags and pause state of a workspace
+	that is created are not applied by the same run, run apply -f again once
+	it is Active.  Other spec changes to an existing workspace
+	are not applied.
 	`,
-	Run: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		paths, _ := cmd.Flags().GetStringSlice("filename")
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "unknown resource '%s' for 'apply'\n\n", args[0])
+			cmd.Usage()
+			c.FinishAudit(1, fmt.Errorf("unknown resource '%s' for 'apply'", args[0]))
+			os.Exit(1)
+		}
+		if len(paths) == 0 {
+			cmd.Help()
+			return
+		}
+		applyManifests(cmd, paths)
+	},
 }
 
 var c *config.Config
@@ -23,3 +64,9 @@ func InitSubCommands(conf *config.Config) *cobra.Command {
 	c = conf
 	return applyCmd
 }
+
+func init() {
+	applyCmd.Flags().StringSliceP("filename", "f", []string{}, "Workspace manifest files or directories to reconcile")
+	applyCmd.Flags().Bool("dry-run", false, "Only show the plan")
+	applyCmd.Flags().BoolP("yes", "y", false, "Run the plan without asking for confirmation")
+}
@@ -1,14 +1,14 @@
 package apply
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -21,7 +21,11 @@ var applyCMSettingsCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl get cm-settings --component ui -o json > ~/tmp/cm-ui.json
 	#edit file
-	splicectl apply cm-settings --component --file ~/tmp/cm-ui.json
+	splicectl apply cm-settings --component ui --file ~/tmp/cm-ui.json
+	# review the changes first
+	splicectl apply cm-settings --component ui --file ~/tmp/cm-ui.json --dry-run
+	# only apply over version 4, fails with exit code 5 if someone stored a newer one
+	splicectl apply cm-settings --component ui --file ~/tmp/cm-ui.json --expected-version 4
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		component, _ := cmd.Flags().GetString("component")
@@ -39,54 +43,38 @@ var applyCMSettingsCmd = &cobra.Command{
 			logrus.Fatal("The input data MUST be in either JSON or YAML format")
 		}
 
-		out, err := setCMSettings(component, jsonBytes)
+		c.EnforceExpectedVersion(client.CMSettings(component), common.ExpectedVersionFlags(cmd), jsonBytes, filePath)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			c.ShowVaultDiff(client.CMSettings(component), jsonBytes, filePath)
+			return
+		}
+
+		out, raw, err := c.APIClient().ApplyVaultDocumentRaw(context.TODO(), client.CMSettings(component), jsonBytes)
 		if err != nil {
-			logrus.WithError(err).Error("Error setting System Settings")
+			c.FatalError(err, "Error setting CM Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplyCmSettingsV1(out)
+				displayApplyCmSettingsV1(out, raw)
 			}
 		}
 	},
 }
 
-func displayApplyCmSettingsV1(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func setCMSettings(comp string, in []byte) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/cmsettings?component=%s", comp)
-	resp, resperr := c.RestyWithHeaders().
-		SetBody(in).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayApplyCmSettingsV1(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	applyCmd.AddCommand(applyCMSettingsCmd)
 
 	applyCMSettingsCmd.Flags().String("file", "", "Specify the input file")
+	applyCMSettingsCmd.Flags().Bool("dry-run", false, "Show the changes the file would make to the latest version, without applying it")
+	common.AddExpectedVersionFlags(applyCMSettingsCmd)
 	applyCMSettingsCmd.Flags().StringP("component", "c", "", "Specify the component, <ui|api>")
 	applyCMSettingsCmd.MarkFlagRequired("file")
 }
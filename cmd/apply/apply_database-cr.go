@@ -1,14 +1,14 @@
 package apply
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -23,6 +23,10 @@ var applyDatabaseCRCmd = &cobra.Command{
     splicectl get database-cr --database-name splicedb -o json > ~/tmp/splicedb.json
     # edit the file
     splicectl apply database-cr --database-name splicedb --file ~/tmp/splicedb.json
+    # review the changes first
+    splicectl apply database-cr --database-name splicedb --file ~/tmp/splicedb.json --dry-run
+    # only apply over version 4, fails with exit code 5 if someone stored a newer one
+    splicectl apply database-cr --database-name splicedb --file ~/tmp/splicedb.json --expected-version 4
 
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -39,7 +43,7 @@ var applyDatabaseCRCmd = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of Databases", dberr)
+				c.FatalError(dberr, "Could not get a list of Databases")
 			}
 		}
 		filePath, _ := cmd.Flags().GetString("file")
@@ -50,16 +54,23 @@ var applyDatabaseCRCmd = &cobra.Command{
 			logrus.Fatal("The input data MUST be in either JSON or YAML format")
 		}
 
-		out, err := setDatabaseCR(databaseName, jsonBytes)
+		c.EnforceExpectedVersion(client.DatabaseCR(databaseName), common.ExpectedVersionFlags(cmd), jsonBytes, filePath)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			c.ShowVaultDiff(client.DatabaseCR(databaseName), jsonBytes, filePath)
+			return
+		}
+
+		out, raw, err := c.APIClient().ApplyVaultDocumentRaw(context.TODO(), client.DatabaseCR(databaseName), jsonBytes)
 		if err != nil {
-			logrus.WithError(err).Error("Error setting Database CR Info")
+			c.FatalError(err, "Error setting Database CR Info")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplyDatabaseCRV1(out)
+				displayApplyDatabaseCRV1(raw)
 			}
 		}
 
@@ -67,46 +78,19 @@ var applyDatabaseCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayApplyDatabaseCRV2(out)
+				displayApplyDatabaseCRV2(out, raw)
 			}
 		}
 
 	},
 }
 
-func displayApplyDatabaseCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayApplyDatabaseCRV2(in string) {
-
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
+func displayApplyDatabaseCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func setDatabaseCR(dbname string, in []byte) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/databasecr?database-name=%s", dbname)
-	resp, resperr := c.RestyWithHeaders().
-		SetBody(in).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting Database CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayApplyDatabaseCRV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -118,6 +102,8 @@ func init() {
 	applyDatabaseCRCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
 	applyDatabaseCRCmd.Flags().StringP("file", "f", "", "Specify the input file")
+	applyDatabaseCRCmd.Flags().Bool("dry-run", false, "Show the changes the file would make to the latest version, without applying it")
+	common.AddExpectedVersionFlags(applyDatabaseCRCmd)
 	// applyDatabaseCRCmd.MarkFlagRequired("database-name")
 	applyDatabaseCRCmd.MarkFlagRequired("file")
 }
@@ -1,56 +1,23 @@
 package apply
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 )
 
-const dataKey = "data"
-
-var (
-	noTopLevelDataError   = errors.New("Default CR did not contain top level 'data' element that is required")
-	dataIsWrongTypeError  = errors.New("data element in Default CR is not an object, but should be")
-	doubleNestedDataError = errors.New("Default CR appears to contain a second level 'data' element, your Default CR appears to be double nested")
-)
-
 // validateDefaultCR - validate that the data representing default-cr contains a top
 // level field named 'data'.
 func validateDefaultCR(defaultCR []byte) (interface{}, error) {
-	// get map representation of Default CR
-	crMap := make(map[string]interface{})
-	if err := json.Unmarshal(defaultCR, &crMap); err != nil {
-		return nil, err
-	}
-
-	// get the data element of the Default CR
-	crData, ok := crMap[dataKey]
-	if !ok {
-		return crMap, noTopLevelDataError
-	}
-
-	// verify that the data element is a map
-	crDataMap, ok := crData.(map[string]interface{})
-	if !ok {
-		return crMap, dataIsWrongTypeError
-	}
-
-	// verify that there is not a data element in the top level data element,
-	// would imply double nesting of Default CR
-	if _, ok := crDataMap[dataKey]; ok {
-		return crData, doubleNestedDataError
-	}
-
-	return crMap, nil
+	return client.ValidateDefaultCR(defaultCR)
 }
 
 var applyDefaultCRCmd = &cobra.Command{
@@ -60,6 +27,10 @@ var applyDefaultCRCmd = &cobra.Command{
 	splicectl get default-cr -o json > ~/tmp/default-cr.json
 	# edit file
 	splicectl apply default-cr --file ~/tmp/default-cr.json
+	# review the changes first
+	splicectl apply default-cr --file ~/tmp/default-cr.json --dry-run
+	# only apply over version 4, fails with exit code 5 if someone stored a newer one
+	splicectl apply default-cr --file ~/tmp/default-cr.json --expected-version 4
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("apply_default-cr")
@@ -75,16 +46,23 @@ var applyDefaultCRCmd = &cobra.Command{
 			logrus.WithError(err).Fatal("Error validating Default CR")
 		}
 
-		out, err := setDefaultCR(jsonBytes)
+		c.EnforceExpectedVersion(client.DefaultCR(), common.ExpectedVersionFlags(cmd), jsonBytes, filePath)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			c.ShowVaultDiff(client.DefaultCR(), jsonBytes, filePath)
+			return
+		}
+
+		out, raw, err := c.APIClient().ApplyVaultDocumentRaw(context.TODO(), client.DefaultCR(), jsonBytes)
 		if err != nil {
-			logrus.WithError(err).Error("Error setting Default CR Info")
+			c.FatalError(err, "Error setting Default CR Info")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplyDefaultCRV1(out)
+				displayApplyDefaultCRV1(raw)
 			}
 		}
 
@@ -92,49 +70,25 @@ var applyDefaultCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayApplyDefaultCRV2(out)
+				displayApplyDefaultCRV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayApplyDefaultCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayApplyDefaultCRV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
+func displayApplyDefaultCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func setDefaultCR(in []byte) (string, error) {
-	uri := "splicectl/v1/vault/defaultcr"
-	resp, resperr := c.RestyWithHeaders().
-		SetBody(in).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayApplyDefaultCRV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	applyCmd.AddCommand(applyDefaultCRCmd)
 
 	applyDefaultCRCmd.Flags().String("file", "", "Specify the input file")
+	applyDefaultCRCmd.Flags().Bool("dry-run", false, "Show the changes the file would make to the latest version, without applying it")
+	common.AddExpectedVersionFlags(applyDefaultCRCmd)
 	applyDefaultCRCmd.MarkFlagRequired("file")
 }
@@ -1,11 +1,16 @@
 package apply
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
 
 	"github.com/spf13/cobra"
 )
@@ -15,6 +20,12 @@ var applyImageTagCmd = &cobra.Command{
 	Short: "Apply an image tag to a cluster resource",
 	Long: `EXAMPLES
 	splicectl apply image-tag --database-name cjdb --component-name zookeeper --tag master-0.0.4
+	splicectl apply image-tag --account-id acme --component-name hbase --tag master-0.0.5 --yes
+
+	--all-active, --all-paused, --account-id, --name-regex and --from-file
+	apply the tag to every workspace they all match instead of one,
+	--max-concurrency at a time.  The selected workspaces are shown and
+	confirmed first and the results are output as a table.
 
 	Supported component-name(s):
 		allspark
@@ -28,47 +39,46 @@ var applyImageTagCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("apply_image-tag")
 
 		componentName, _ := cmd.Flags().GetString("component-name")
+		tag, _ := cmd.Flags().GetString("tag")
+		bulk, err := common.BulkFlags(cmd)
+		if err != nil {
+			c.FatalError(err, "Invalid selectors")
+		}
+		if bulk.Selected() {
+			c.RunBulk(config.BulkCommand{
+				Verb: "apply the image tag to",
+				Run: func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error) {
+					return api.SetImageTag(ctx, database, componentName, tag)
+				},
+			}, bulk, common.WaitOptions{})
+			return
+		}
+
 		databaseName, _ := cmd.Flags().GetString("database-name")
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of Databases", dberr)
+				c.FatalError(dberr, "Could not get a list of Databases")
 			}
 		}
 
-		tag, _ := cmd.Flags().GetString("tag")
-		out, err := setDatabaseImageTag(componentName, databaseName, tag)
+		_, raw, err := c.APIClient().SetImageTagRaw(context.TODO(), databaseName, componentName, tag)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting image tag for component")
+			c.FatalError(err, "Error setting image tag for component")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.16"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplyImageTagV1(out)
+				displayApplyImageTagV1(raw)
 			}
 		}
 	},
 }
 
-func displayApplyImageTagV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func setDatabaseImageTag(componentName string, databaseName string, imageTag string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/splicedb/imagetag?component-name=%s&database-name=%s&tag=%s",
-		componentName, databaseName, imageTag)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting TAG for database")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
+func displayApplyImageTagV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
 func init() {
@@ -78,6 +88,8 @@ func init() {
 	applyImageTagCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
 	applyImageTagCmd.Flags().StringP("tag", "t", "", "Specify the image tag, ie: master-246")
 
+	common.AddBulkFlags(applyImageTagCmd)
+
 	applyImageTagCmd.MarkFlagRequired("component-name")
 	applyImageTagCmd.MarkFlagRequired("tag")
 
@@ -0,0 +1,223 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// imageTagLookup - the image tag a component of a workspace is set to
+type imageTagLookup func(database, component string) (string, error)
+
+// applyManifests - reconcile the Workspace manifests found in paths, the
+// plan is shown and confirmed before anything is changed
+func applyManifests(cmd *cobra.Command, paths []string) {
+	c.VersionDetail.RequirementMet("apply_workspace")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+
+	docs, err := common.ReadManifests(paths)
+	if err != nil {
+		logrus.WithError(err).Fatal("Could not read the manifests")
+	}
+	manifests, err := decodeManifests(docs)
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid manifest")
+	}
+
+	databases, err := c.GetDatabaseListStruct()
+	if err != nil {
+		c.FatalError(err, "Could not get a list of workspaces")
+	}
+	plan, err := planWorkspaces(manifests, databases, currentImageTag)
+	if err != nil {
+		c.FatalError(err, "Could not plan the changes")
+	}
+
+	c.OutputData(plan)
+	if dryRun || !plan.HasChanges() {
+		return
+	}
+	if !assumeYes {
+		run, err := c.PromptForConfirm("Apply this plan?", false)
+		if err != nil {
+			c.FatalError(err, "Apply cancelled")
+		}
+		if !run {
+			logrus.Fatal("Apply cancelled")
+		}
+	}
+
+	byName := map[string]*objects.WorkspaceManifest{}
+	for i := range manifests {
+		byName[manifests[i].Metadata.Name] = &manifests[i]
+	}
+	failed := 0
+	var firstErr error
+	for i := range plan.Actions {
+		action := &plan.Actions[i]
+		if !action.Changes() {
+			continue
+		}
+		if err := runAction(action, byName[action.Workspace]); err != nil {
+			action.Result = fmt.Sprintf("failed: %v", err)
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		action.Result = "done"
+	}
+
+	fmt.Println()
+	c.OutputData(plan)
+	if failed > 0 {
+		c.FatalError(fmt.Errorf("%d actions failed, the first with: %w", failed, firstErr), "The plan did not complete")
+	}
+}
+
+// decodeManifests - the Workspace manifests in docs, checked for the fields
+// the plan relies on
+func decodeManifests(docs []common.ManifestDocument) ([]objects.WorkspaceManifest, error) {
+	manifests := []objects.WorkspaceManifest{}
+	seen := map[string]string{}
+	for _, doc := range docs {
+		m := objects.WorkspaceManifest{}
+		if err := json.Unmarshal(doc.JSON, &m); err != nil {
+			return nil, fmt.Errorf("%v; %s", err, doc.Source)
+		}
+		m.Source = doc.Source
+		switch {
+		case m.APIVersion != objects.ManifestAPIVersion:
+			return nil, fmt.Errorf("%s: apiVersion must be %s", doc.Source, objects.ManifestAPIVersion)
+		case m.Kind != objects.WorkspaceKind:
+			return nil, fmt.Errorf("%s: kind '%s' is not supported, only %s", doc.Source, m.Kind, objects.WorkspaceKind)
+		case len(m.Metadata.Name) == 0:
+			return nil, fmt.Errorf("%s: metadata.name is required", doc.Source)
+		case len(m.Spec.Name) > 0 && m.Spec.Name != m.Metadata.Name:
+			return nil, fmt.Errorf("%s: spec.name '%s' does not match metadata.name '%s'", doc.Source, m.Spec.Name, m.Metadata.Name)
+		}
+		if other, ok := seen[m.Metadata.Name]; ok {
+			return nil, fmt.Errorf("workspace %s is declared in both %s and %s", m.Metadata.Name, other, doc.Source)
+		}
+		seen[m.Metadata.Name] = doc.Source
+
+		m.Spec.Name = m.Metadata.Name
+		m.Spec.CloudProvider = strings.ToUpper(m.Spec.CloudProvider)
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// planWorkspaces - the actions that bring the workspaces in line with the
+// manifests.  A workspace being resumed is resumed before its image tags
+// are set, one being paused is paused after.
+func planWorkspaces(manifests []objects.WorkspaceManifest, databases *objects.DatabaseList, imageTag imageTagLookup) (*objects.WorkspacePlan, error) {
+	plan := &objects.WorkspacePlan{Actions: []objects.PlanAction{}}
+	for _, m := range manifests {
+		name := m.Metadata.Name
+		database := databases.Find(name)
+		if database == nil {
+			action := objects.PlanAction{
+				Workspace: name,
+				Action:    objects.PlanCreate,
+				Detail:    fmt.Sprintf("account %s on %s", m.Spec.AccountID, m.Spec.CloudProvider),
+			}
+			if len(m.Spec.ImageTags) > 0 || m.Spec.Paused != nil {
+				action.Detail += ", re-run apply -f once it is Active to set its image tags and pause state"
+			}
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
+		actions := []objects.PlanAction{}
+		var pauseAction *objects.PlanAction
+		if m.Spec.Paused != nil {
+			switch {
+			case *m.Spec.Paused && database.Status == config.StateActive:
+				pauseAction = &objects.PlanAction{Workspace: name, Action: objects.PlanPause, From: database.Status, To: config.StatePaused}
+			case !*m.Spec.Paused && database.Status == config.StatePaused:
+				actions = append(actions, objects.PlanAction{Workspace: name, Action: objects.PlanResume, From: database.Status, To: config.StateActive})
+			case *m.Spec.Paused && database.Status != config.StatePaused, !*m.Spec.Paused && database.Status != config.StateActive:
+				actions = append(actions, objects.PlanAction{Workspace: name, Action: objects.PlanSkip, From: database.Status, Detail: "can only pause or resume from Active or Paused"})
+			}
+		}
+
+		components := make([]string, 0, len(m.Spec.ImageTags))
+		for component := range m.Spec.ImageTags {
+			components = append(components, component)
+		}
+		sort.Strings(components)
+		for _, component := range components {
+			current, err := imageTag(name, component)
+			if err != nil {
+				return nil, fmt.Errorf("%v; could not get the %s image tag of %s", err, component, name)
+			}
+			if want := m.Spec.ImageTags[component]; current != want {
+				actions = append(actions, objects.PlanAction{Workspace: name, Action: objects.PlanSetImageTag, Component: component, From: current, To: want})
+			}
+		}
+
+		if pauseAction != nil {
+			actions = append(actions, *pauseAction)
+		}
+		if len(actions) == 0 {
+			actions = append(actions, objects.PlanAction{Workspace: name, Action: objects.PlanUnchanged, From: database.Status})
+		}
+		plan.Actions = append(plan.Actions, actions...)
+	}
+	return plan, nil
+}
+
+// currentImageTag - the image tag in the CR of the workspace for component
+func currentImageTag(database, component string) (string, error) {
+	tags, err := c.APIClient().GetImageTags(context.TODO(), database, component)
+	if err != nil {
+		return "", err
+	}
+	for _, tag := range tags.ImageTags {
+		if strings.EqualFold(tag.Component, component) {
+			return tag.DatabaseCRImage, nil
+		}
+	}
+	return "", nil
+}
+
+// runAction - performs one step of the plan
+func runAction(action *objects.PlanAction, m *objects.WorkspaceManifest) error {
+	ctx := context.TODO()
+	message := fmt.Sprintf("splicectl apply -f %s", m.Source)
+
+	var status *objects.ActionStatus
+	var err error
+	switch action.Action {
+	case objects.PlanCreate:
+		status, err = c.APIClient().CreateDatabase(ctx, &m.Spec.DatabaseRequest)
+	case objects.PlanSetImageTag:
+		status, err = c.APIClient().SetImageTag(ctx, action.Workspace, action.Component, action.To)
+	case objects.PlanPause:
+		status, err = c.APIClient().Pause(ctx, action.Workspace, message)
+	case objects.PlanResume:
+		status, err = c.APIClient().Resume(ctx, action.Workspace, message)
+	default:
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%s: %s %s\n", action.Workspace, action.Action, action.Component)
+	if err != nil {
+		return err
+	}
+	if status != nil && !status.Success && len(status.Error) > 0 {
+		return fmt.Errorf("%s", status.Error)
+	}
+	return nil
+}
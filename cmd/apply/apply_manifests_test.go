@@ -0,0 +1,95 @@
+package apply
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+const workspaceManifests = `apiVersion: splicectl/v1
+kind: Workspace
+metadata:
+  name: newdb
+spec:
+  accountId: acct-1
+  cloudProvider: aws
+  imageTags:
+    hbase: master-250
+---
+apiVersion: splicectl/v1
+kind: Workspace
+metadata:
+  name: splicedb
+spec:
+  imageTags:
+    hbase: master-250
+    zookeeper: master-1
+  paused: true
+---
+apiVersion: splicectl/v1
+kind: Workspace
+metadata:
+  name: pauseddb
+spec:
+  paused: false
+`
+
+func TestPlanWorkspaces(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "workspaces.yaml"), []byte(workspaceManifests), 0600); err != nil {
+		t.Fatal(err)
+	}
+	docs, err := common.ReadManifests([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifests, err := decodeManifests(docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) != 3 || manifests[0].Spec.Name != "newdb" || manifests[0].Spec.CloudProvider != "AWS" {
+		t.Fatalf("unexpected manifests %+v", manifests)
+	}
+
+	databases := &objects.DatabaseList{Clusters: []objects.CMClusterInfo{
+		{DcosAppId: "splicedb", Status: "Active"},
+		{DcosAppId: "pauseddb", Status: "Paused"},
+	}}
+	tags := func(database, component string) (string, error) {
+		return map[string]string{"hbase": "master-246", "zookeeper": "master-1"}[component], nil
+	}
+	plan, err := planWorkspaces(manifests, databases, tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []objects.PlanAction{
+		{Workspace: "newdb", Action: objects.PlanCreate},
+		{Workspace: "splicedb", Action: objects.PlanSetImageTag, Component: "hbase", From: "master-246", To: "master-250"},
+		{Workspace: "splicedb", Action: objects.PlanPause},
+		{Workspace: "pauseddb", Action: objects.PlanResume},
+	}
+	if len(plan.Actions) != len(expected) {
+		t.Fatalf("expected %d actions, got %+v", len(expected), plan.Actions)
+	}
+	for i, a := range plan.Actions {
+		e := expected[i]
+		if a.Workspace != e.Workspace || a.Action != e.Action || a.Component != e.Component || (len(e.To) > 0 && a.To != e.To) {
+			t.Errorf("action %d: expected %+v, got %+v", i, e, a)
+		}
+	}
+	if detail := plan.Actions[0].Detail; !strings.Contains(detail, "re-run apply -f once it is Active") {
+		t.Errorf("the create action should say the image tags need another run, got '%s'", detail)
+	}
+}
+
+func TestDecodeManifestsRejectsUnknownKind(t *testing.T) {
+	docs := []common.ManifestDocument{{Source: "x.yaml", JSON: []byte(`{"apiVersion":"splicectl/v1","kind":"Database","metadata":{"name":"a"}}`)}}
+	if _, err := decodeManifests(docs); err == nil {
+		t.Error("expected an error for kind Database")
+	}
+}
@@ -1,14 +1,14 @@
 package apply
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -22,6 +22,10 @@ var applySystemSettingsCmd = &cobra.Command{
 	splicectl get system-settings -o json > ~/tmp/system-settings.json
 	#edit file
 	splicectl apply system-settings --file ~/tmp/system-settings.json
+	# review the changes first
+	splicectl apply system-settings --file ~/tmp/system-settings.json --dry-run
+	# only apply over version 4, fails with exit code 5 if someone stored a newer one
+	splicectl apply system-settings --file ~/tmp/system-settings.json --expected-version 4
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("apply_system-settings")
@@ -34,16 +38,23 @@ var applySystemSettingsCmd = &cobra.Command{
 			logrus.Fatal("The input data MUST be in either JSON or YAML format")
 		}
 
-		out, err := setSystemSettings(jsonBytes)
+		c.EnforceExpectedVersion(client.SystemSettings(), common.ExpectedVersionFlags(cmd), jsonBytes, filePath)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			c.ShowVaultDiff(client.SystemSettings(), jsonBytes, filePath)
+			return
+		}
+
+		out, raw, err := c.APIClient().ApplyVaultDocumentRaw(context.TODO(), client.SystemSettings(), jsonBytes)
 		if err != nil {
-			logrus.WithError(err).Error("Error setting System Settings")
+			c.FatalError(err, "Error setting System Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplySystemSettingsV1(out)
+				displayApplySystemSettingsV1(raw)
 			}
 		}
 
@@ -51,50 +62,26 @@ var applySystemSettingsCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayApplySystemSettingsV2(out)
+				displayApplySystemSettingsV2(out, raw)
 			}
 		}
 
 	},
 }
 
-func displayApplySystemSettingsV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayApplySystemSettingsV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
+func displayApplySystemSettingsV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func setSystemSettings(in []byte) (string, error) {
-	uri := "splicectl/v1/vault/systemsettings"
-	resp, resperr := c.RestyWithHeaders().
-		SetBody(in).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayApplySystemSettingsV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	applyCmd.AddCommand(applySystemSettingsCmd)
 
 	applySystemSettingsCmd.Flags().String("file", "", "Specify the input file")
+	applySystemSettingsCmd.Flags().Bool("dry-run", false, "Show the changes the file would make to the latest version, without applying it")
+	common.AddExpectedVersionFlags(applySystemSettingsCmd)
 	applySystemSettingsCmd.MarkFlagRequired("file")
 }
@@ -1,14 +1,15 @@
 package apply
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -22,6 +23,10 @@ var applyVaultKeyCmd = &cobra.Command{
 	splicectl get vault-key --keypath services/cloudmanager/config/default/ui -o json > ~/tmp/cm-ui.json
 	# edit file
 	splicectl apply vault-key --keypath services/cloudmanager/config/default/ui --file ~/tmp/cm-ui.json
+	# review the changes first
+	splicectl apply vault-key --keypath services/cloudmanager/config/default/ui --file ~/tmp/cm-ui.json --dry-run
+	# only apply over version 4, fails with exit code 5 if someone stored a newer one
+	splicectl apply vault-key --keypath services/cloudmanager/config/default/ui --file ~/tmp/cm-ui.json --expected-version 4
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("apply_vault-key")
@@ -38,16 +43,23 @@ var applyVaultKeyCmd = &cobra.Command{
 			logrus.Fatal("The input data MUST be in either JSON or YAML format")
 		}
 
-		out, err := setVaultKeyData(keyPath, jsonBytes)
+		c.EnforceExpectedVersion(client.VaultKey(keyPath), common.ExpectedVersionFlags(cmd), jsonBytes, filePath)
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			c.ShowVaultDiff(client.VaultKey(keyPath), jsonBytes, filePath)
+			return
+		}
+
+		out, raw, err := c.APIClient().ApplyVaultDocumentRaw(context.TODO(), client.VaultKey(keyPath), jsonBytes)
 		if err != nil {
-			logrus.WithError(err).Error("Error setting Vault-Key Data")
+			c.FatalError(err, "Error setting Vault-Key Data")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayApplyVaultKeyV1(out)
+				displayApplyVaultKeyV1(raw)
 			}
 		}
 
@@ -55,45 +67,18 @@ var applyVaultKeyCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayApplyVaultKeyV2(out)
+				displayApplyVaultKeyV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayApplyVaultKeyV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayApplyVaultKeyV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
+func displayApplyVaultKeyV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func setVaultKeyData(keypath string, in []byte) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/vaultkey?keypath=%s", keypath)
-	resp, resperr := c.RestyWithHeaders().
-		SetBody(in).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error setting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayApplyVaultKeyV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -101,6 +86,8 @@ func init() {
 
 	applyVaultKeyCmd.Flags().String("keypath", "", "Specify the vault key path")
 	applyVaultKeyCmd.Flags().String("file", "", "Specify the input file")
+	applyVaultKeyCmd.Flags().Bool("dry-run", false, "Show the changes the file would make to the latest version, without applying it")
+	common.AddExpectedVersionFlags(applyVaultKeyCmd)
 	applyVaultKeyCmd.MarkFlagRequired("keypath")
 	applyVaultKeyCmd.MarkFlagRequired("file")
 }
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Query the local audit log of commands that changed something",
+	Long: `EXAMPLES
+	splicectl audit list
+	splicectl audit list --since 24h --command apply
+	splicectl audit list --database splicedb -o json
+
+	Every apply, create, delete, edit, pause, restart, resume and rollback run
+	from this workstation, and every pause, resume and restart made from
+	'splicectl ui', is appended to ~/.splicectl/audit.log as one JSON
+	line, secret values are redacted and the session id is stored as a hash.
+	The log is rotated at 10MB keeping 5 older files.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return auditCmd
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+var listAuditCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the records in the audit log, oldest first.",
+	Long: `EXAMPLES
+	splicectl audit list
+	splicectl audit list --since 7d
+	splicectl audit list --since 2021-06-01 --command "apply vault-key"
+	splicectl audit list --database splicedb -o yaml
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		command, _ := cmd.Flags().GetString("command")
+		database, _ := cmd.Flags().GetString("database")
+
+		var start time.Time
+		if len(since) > 0 {
+			var err error
+			if start, err = common.ParseSince(since, time.Now()); err != nil {
+				logrus.WithError(err).Fatal("Invalid --since")
+			}
+		}
+
+		path, err := common.AuditLogPath()
+		if err != nil {
+			logrus.WithError(err).Fatal("Could not locate the audit log")
+		}
+		records, err := common.ReadAuditLog(path)
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading the audit log")
+		}
+
+		list := &objects.AuditRecordList{Records: []objects.AuditRecord{}}
+		for _, rec := range records {
+			if !start.IsZero() && rec.Timestamp.Before(start) {
+				continue
+			}
+			if len(command) > 0 && rec.Command != command && !strings.HasPrefix(rec.Command, command+" ") {
+				continue
+			}
+			if len(database) > 0 && rec.Database != database {
+				continue
+			}
+			list.Records = append(list.Records, rec)
+		}
+
+		c.OutputData(list)
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(listAuditCmd)
+
+	listAuditCmd.Flags().String("since", "", "Only records newer than a duration (24h, 7d) or a date (2006-01-02)")
+	listAuditCmd.Flags().String("command", "", "Only records of a command, ie: apply or \"apply vault-key\"")
+	listAuditCmd.Flags().StringP("database", "d", "", "Only records for a database")
+}
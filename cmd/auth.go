@@ -1,24 +1,15 @@
 package cmd
 
 import (
-	"crypto/tls"
-	"crypto/x509"
+	"context"
 	"encoding/json"
 	"fmt"
 
-	"github.com/spf13/viper"
-
-	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
 )
 
-// SessionData - Session Authorization Info
-type SessionData struct {
-	SessionID  string `json:"session_id"`
-	ValidUntil string `json:"valid_until"`
-}
-
 var authCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Request an auth session",
@@ -27,12 +18,6 @@ var authCmd = &cobra.Command{
 	Aliases: []string{"login"},
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// var authClient auth.Client
-		// environment := getEnvironmentName()
-		// authClient = auth.NewAuth(environment, common.SessionData{
-		// 	SessionID:  fmt.Sprintf("%s", viper.Get(fmt.Sprintf("%s-session_id", environment))),
-		// 	ValidUntil: fmt.Sprintf("%s", viper.Get(fmt.Sprintf("%s-valid_until", environment))),
-		// })
 		if pass := c.AuthClient.CheckTokenValidity(); pass {
 			sessData, err := json.Marshal(c.AuthClient.GetSession())
 			if err != nil {
@@ -41,55 +26,27 @@ var authCmd = &cobra.Command{
 			}
 			fmt.Println(string(sessData[:]))
 		} else {
-			out, err := performAuth()
+			// The auth request is made before we hold a session, so no
+			// credentials are sent with it.
+			response, err := client.New(c.ApiServer, client.WithCABundle(c.CABundle)).Auth(context.TODO())
 			if err != nil {
-				logrus.WithError(err).Error("Error getting AUTH Info")
-			}
-			var response SessionData
-			marsherr := json.Unmarshal([]byte(out), &response)
-			if marsherr != nil {
-				logrus.WithError(marsherr).Error("Error decoding json")
+				c.FatalError(err, "Error getting AUTH Info")
 			}
 			environment := getEnvironmentName()
-			viper.Set(fmt.Sprintf("%s-session_id", environment), response.SessionID)
-			viper.Set(fmt.Sprintf("%s-valid_until", environment), response.ValidUntil)
-			verr := viper.WriteConfig()
-			if verr != nil {
+			if verr := c.SaveSession(environment, *response); verr != nil {
 				logrus.WithError(verr).Info("Failed to write config")
 			}
-			fmt.Println(out)
+			sessData, err := json.Marshal(response)
+			if err != nil {
+				logrus.WithError(err).Error("Error converting session data to JSON")
+				return
+			}
+			fmt.Println(string(sessData[:]))
 		}
 
 	},
 }
 
-func performAuth() (string, error) {
-	restClient := resty.New()
-	// Check if we've set a caBundle (via --ca-cert parameter)
-	if len(c.CABundle) > 0 {
-		roots := x509.NewCertPool()
-		ok := roots.AppendCertsFromPEM([]byte(c.CABundle))
-		if !ok {
-			logrus.Info("Failed to parse CABundle")
-		}
-		restClient.SetTLSClientConfig(&tls.Config{RootCAs: roots})
-	}
-
-	uri := "splicectl/v1/auth"
-	resp, resperr := restClient.R().
-		SetHeader("Content-Type", "application/json").
-		SetHeader("Accept", "application/json").
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
-}
-
 func init() {
 	RootCmd.AddCommand(authCmd)
 }
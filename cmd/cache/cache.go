@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Manage the local cache of answers from the cluster",
+	Long: `EXAMPLES
+	splicectl cache clear
+	splicectl cache clear --all
+
+	The version of the API server, the environment name, the ingress host and
+	the database list are cached per context under ~/.splicectl/cache, for 10
+	minutes, an hour, an hour and 30 seconds respectively.  Commands that
+	change a workspace drop the cached database list, --no-cache asks again
+	for a single command.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return cacheCmd
+}
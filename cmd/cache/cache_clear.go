@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/common"
+)
+
+var clearCacheCmd = &cobra.Command{
+	Use:   "clear",
+	Args:  cobra.NoArgs,
+	Short: "Remove the cached answers of the current context.",
+	Long: `EXAMPLES
+	splicectl cache clear
+	splicectl cache clear --context staging
+	splicectl cache clear --all
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+
+		if !all {
+			if c.Cache == nil {
+				c.FatalError(errors.New("the cache is not available"), "Could not clear the cache")
+			}
+			if err := c.Cache.Clear(); err != nil {
+				c.FatalError(err, "Could not clear the cache")
+			}
+			fmt.Printf("Cleared %s\n", c.Cache.Dir)
+			return
+		}
+
+		dir, err := common.CacheRoot()
+		if err != nil {
+			c.FatalError(err, "Could not locate the cache")
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			c.FatalError(err, "Could not clear the cache")
+		}
+		fmt.Printf("Cleared %s\n", dir)
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(clearCacheCmd)
+
+	clearCacheCmd.Flags().Bool("all", false, "Clear the cache of every context")
+}
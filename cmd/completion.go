@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh|fish|powershell>",
+	Short: "Generate the shell completion script",
+	Long: `EXAMPLES
+	# bash, the bash-completion package must be installed
+	source <(splicectl completion bash)
+	splicectl completion bash > /etc/bash_completion.d/splicectl
+
+	# zsh, compinit must be loaded
+	source <(splicectl completion zsh)
+	splicectl completion zsh > "${fpath[1]}/_splicectl"
+
+	# fish
+	splicectl completion fish > ~/.config/fish/completions/splicectl.fish
+
+	# powershell
+	splicectl completion powershell | Out-String | Invoke-Expression
+
+	Besides the commands and flags, the names of the workspaces, the image
+	components, the cm-settings components, the cloud providers, the vault
+	versions of a resource and recently used vault key paths are completed.
+	Answers from the API server are cached for a short while so a tab press
+	stays fast.
+`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.ExactValidArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		switch args[0] {
+		case "bash":
+			err = RootCmd.GenBashCompletion(os.Stdout)
+		case "zsh":
+			_, err = fmt.Fprintf(os.Stdout, zshCompletion, RootCmd.Name(), cobra.ShellCompRequestCmd)
+		case "fish":
+			err = RootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			_, err = fmt.Fprintf(os.Stdout, powershellCompletion, RootCmd.Name(), cobra.ShellCompRequestCmd)
+		}
+		if err != nil {
+			c.FatalError(err, "Could not write the completion script")
+		}
+	},
+}
+
+// zshCompletion - asks splicectl for the completions of the words typed so
+// far, the last line of the answer is ':<directive>'
+const zshCompletion = `#compdef %[1]s
+
+_%[1]s() {
+  local out directive lastLine comp value
+  local -a completions opts
+  local requestComp="${words[1]} %[2]s ${words[2,CURRENT]}"
+  if [ -z "${words[CURRENT]}" ]; then
+    # the last word is complete, an empty argument asks for the next one
+    requestComp="${requestComp} \"\""
+  fi
+  out=$(eval ${requestComp} 2>/dev/null)
+
+  lastLine=${out##*$'\n'}
+  directive=0
+  if [[ ${lastLine} == :* ]]; then
+    directive=${lastLine#:}
+    if [[ ${out} == *$'\n'* ]]; then
+      out=${out%%$'\n'*}
+    else
+      out=""
+    fi
+  fi
+  if (( directive & 1 )); then
+    return 1
+  fi
+
+  for comp in ${(f)out}; do
+    value=${comp%%%%$'\t'*}
+    value=${value//:/\\:}
+    if [[ ${comp} == *$'\t'* ]]; then
+      completions+=("${value}:${comp#*$'\t'}")
+    else
+      completions+=("${value}")
+    fi
+  done
+
+  if (( ${#completions} == 0 )); then
+    if (( directive & 4 )); then
+      return 1
+    fi
+    _files
+    return
+  fi
+  if (( directive & 2 )); then
+    opts=(-S '')
+  fi
+  _describe 'completions' completions ${opts}
+}
+
+if [ "${funcstack[1]}" = "_%[1]s" ]; then
+  _%[1]s "$@"
+else
+  compdef _%[1]s %[1]s
+fi
+`
+
+// powershellCompletion - the same exchange as zsh, words are taken up to
+// the cursor
+const powershellCompletion = `Register-ArgumentCompleter -Native -CommandName '%[1]s' -ScriptBlock {
+    param($WordToComplete, $CommandAst, $CursorPosition)
+
+    $Line = $CommandAst.Extent.Text
+    $Line = $Line.Substring(0, [Math]::Min($Line.Length, $CursorPosition - $CommandAst.Extent.StartOffset))
+    $Program, $Arguments = $Line.Split(" ", 2)
+    $RequestComp = "$Program %[2]s $Arguments"
+    if ($WordToComplete -eq "") {
+        # the last word is complete, an empty argument asks for the next one
+        $RequestComp = "$RequestComp ''"
+    }
+    $Out = @(Invoke-Expression -Command $RequestComp 2>$null)
+    if ($Out.Length -eq 0) {
+        return
+    }
+
+    $Directive = 0
+    if ($Out[-1] -match '^:(\d+)$') {
+        $Directive = [int]$Matches[1]
+        $Out = @($Out | Select-Object -SkipLast 1)
+    }
+    if ($Directive -band 1) {
+        return
+    }
+
+    $Out | Where-Object { $_ -ne "" } | ForEach-Object {
+        $Name, $Description = $_.Split("` + "`" + `t", 2)
+        if (-not $Description) {
+            $Description = $Name
+        }
+        [System.Management.Automation.CompletionResult]::new($Name, $Name, 'ParameterValue', $Description)
+    }
+}
+`
+
+func init() {
+	RootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/splicemachine/splicectl/auth"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+const (
+	// completionTTL - how long answers from the API server are reused while
+	// completing, a few tab presses in a row only ask once
+	completionTTL = 30 * time.Second
+
+	// completionTimeout - a tab press gives up on the API server after this
+	completionTimeout = 10 * time.Second
+)
+
+// completionConnected - the context, server and session are set up once
+var completionConnected bool
+
+// completionConnect - the part of PersistentPreRun an API call needs, only
+// done when the cache can't answer
+func completionConnect() error {
+	if completionConnected {
+		return nil
+	}
+	selectServer()
+	environment := getEnvironmentName()
+	c.Environment = environment
+	c.AuthClient = auth.NewAuth(environment, c.SessionFor(environment))
+	if !c.AuthClient.CheckTokenValidity() {
+		return config.ErrSessionExpired
+	}
+	completionConnected = true
+	return nil
+}
+
+// cachedCompletions - the completions stored under key, fetched from the API
+// server when they are missing or stale
+func cachedCompletions(key string, fetch func(context.Context, *client.Client) ([]string, error)) ([]string, error) {
+	// completions skip PersistentPreRun so the context is selected here
+	if err := c.UseContext(contextName); err != nil {
+		return nil, err
+	}
+	c.UseCache()
+	values := []string{}
+	if c.CacheGet(key, completionTTL, &values) {
+		return values, nil
+	}
+	if err := completionConnect(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	values, err := fetch(ctx, c.APIClient())
+	if err != nil {
+		return nil, err
+	}
+	c.CachePut(key, values)
+	return values, nil
+}
+
+// matchPrefix - the completions whose value, the part before the tab,
+// starts with what has been typed
+func matchPrefix(values []string, toComplete string) []string {
+	matches := []string{}
+	for _, v := range values {
+		if strings.HasPrefix(strings.SplitN(v, "\t", 2)[0], toComplete) {
+			matches = append(matches, v)
+		}
+	}
+	return matches
+}
+
+// completeWith - a completion function offering a fixed list
+func completeWith(values []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionFailed - the error goes to stderr, the shell offers nothing
+func completionFailed(err error) ([]string, cobra.ShellCompDirective) {
+	cobra.CompErrorln(err.Error())
+	return nil, cobra.ShellCompDirectiveError
+}
+
+// completeDatabases - the workspace names, with their status as description
+func completeDatabases(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values, err := cachedCompletions("completion-databases", func(ctx context.Context, api *client.Client) ([]string, error) {
+		list, err := api.ListDatabases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(list.Clusters))
+		for _, db := range list.Clusters {
+			names = append(names, fmt.Sprintf("%s\t%s", db.DcosAppId, db.Status))
+		}
+		sort.Strings(names)
+		return names, nil
+	})
+	if err != nil {
+		return completionFailed(err)
+	}
+	return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// flagValue - the value typed for the first of the named flags that is set
+func flagValue(cmd *cobra.Command, names ...string) string {
+	for _, name := range names {
+		if f := cmd.Flags().Lookup(name); f != nil && len(f.Value.String()) > 0 {
+			return f.Value.String()
+		}
+	}
+	return ""
+}
+
+// completionResource - the vault resource a command with --version works
+// on, false when what has been typed so far doesn't name one yet
+func completionResource(cmd *cobra.Command) (client.VaultResource, bool) {
+	switch cmd.Name() {
+	case "default-cr":
+		return client.DefaultCR(), true
+	case "system-settings":
+		return client.SystemSettings(), true
+	case "database-cr":
+		db := flagValue(cmd, "database-name", "workspace", "database")
+		return client.DatabaseCR(db), len(db) > 0
+	case "cm-settings":
+		component := flagValue(cmd, "component")
+		return client.CMSettings(component), len(component) > 0
+	case "vault-key":
+		keyPath := flagValue(cmd, "keypath")
+		return client.VaultKey(keyPath), len(keyPath) > 0
+	}
+	return client.VaultResource{}, false
+}
+
+// completeVersions - the vault versions of the resource of the command,
+// newest first with the time they were created as description
+func completeVersions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	r, ok := completionResource(cmd)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	values, err := cachedCompletions("completion-versions-"+r.String(), func(ctx context.Context, api *client.Client) ([]string, error) {
+		list, err := api.ListVersions(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		versions := append([]objects.VaultVersion{}, list.Versions...)
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+		values := make([]string, 0, len(versions))
+		for _, v := range versions {
+			values = append(values, fmt.Sprintf("%d\t%s", v.Version, v.CreatedTime))
+		}
+		return values, nil
+	})
+	if err != nil {
+		return completionFailed(err)
+	}
+	return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeKeyPaths - the vault key paths used by recent commands, there is
+// no API to list them so the audit log is the only source
+func completeKeyPaths(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	path, err := common.AuditLogPath()
+	if err != nil {
+		return completionFailed(err)
+	}
+	records, err := common.ReadAuditLog(path)
+	if err != nil {
+		return completionFailed(err)
+	}
+	seen := map[string]bool{}
+	values := []string{}
+	// newest first
+	for i := len(records) - 1; i >= 0; i-- {
+		for _, arg := range records[i].Args {
+			keyPath := strings.TrimPrefix(arg, "--keypath=")
+			if keyPath == arg || len(keyPath) == 0 || seen[keyPath] {
+				continue
+			}
+			seen[keyPath] = true
+			values = append(values, keyPath)
+		}
+	}
+	return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeContexts - the names of the contexts in the config file
+func completeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	list, err := config.GetContextList()
+	if err != nil {
+		return completionFailed(err)
+	}
+	values := make([]string, 0, len(list.Contexts))
+	for _, ctx := range list.Contexts {
+		values = append(values, fmt.Sprintf("%s\t%s", ctx.Name, ctx.ServerURI))
+	}
+	return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// flagCompletions - the completion function of each flag name, whichever
+// command the flag is on
+var flagCompletions = map[string]func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective){
+	"database-name":  completeDatabases,
+	"workspace":      completeDatabases,
+	"database":       completeDatabases,
+	"component-name": completeWith(objects.ImageTagComponents),
+	"component":      completeWith([]string{"ui", "api"}),
+	"cloud-provider": completeWith(cspList),
+	"version":        completeVersions,
+	"keypath":        completeKeyPaths,
+	"context":        completeContexts,
+	"type":           completeWith([]string{"Normal", "Warning"}),
+}
+
+// registerCompletions - adds the flag completions to every command of the
+// tree, cobra keeps them per flag so each flag is registered once
+func registerCompletions(cmd *cobra.Command) {
+	register := func(f *pflag.Flag) {
+		if fn, ok := flagCompletions[f.Name]; ok {
+			if err := cmd.RegisterFlagCompletionFunc(f.Name, fn); err != nil {
+				logrus.WithError(err).Debugf("Could not register the completion of --%s", f.Name)
+			}
+		}
+	}
+	cmd.LocalNonPersistentFlags().VisitAll(register)
+	cmd.PersistentFlags().VisitAll(register)
+	for _, child := range cmd.Commands() {
+		registerCompletions(child)
+	}
+}
+
+// registerArgCompletions - the commands whose arguments are completed
+func registerArgCompletions() {
+	for _, path := range [][]string{{"context", "use"}, {"context", "delete"}} {
+		if cmd, _, err := RootCmd.Find(path); err == nil {
+			cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				// they take one context name
+				if len(args) > 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return completeContexts(cmd, args, toComplete)
+			}
+		}
+	}
+}
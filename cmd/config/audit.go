@@ -0,0 +1,115 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+var auditHookOnce sync.Once
+
+// StartAudit - begins the audit record of a mutating command, it is written
+// to the audit log by FinishAudit, FatalError or a fatal log entry.
+func (c *Config) StartAudit(command string, args []string, database, environment string) {
+	rec := &objects.AuditRecord{
+		Timestamp:   time.Now().UTC(),
+		Environment: environment,
+		APIHost:     c.ApiServer,
+		Command:     command,
+		Args:        args,
+		Database:    database,
+	}
+	if u, err := user.Current(); err == nil {
+		rec.User = u.Username
+	}
+	if c.Context != nil {
+		rec.Context = c.Context.Name
+	}
+	if c.AuthClient != nil && len(c.AuthClient.GetSessionID()) > 0 {
+		sum := sha256.Sum256([]byte(c.AuthClient.GetSessionID()))
+		rec.SessionHash = hex.EncodeToString(sum[:])[:16]
+	}
+	auditHookOnce.Do(func() {
+		logrus.AddHook(&auditHook{c: c})
+	})
+	c.auditMu.Lock()
+	c.audit = rec
+	c.auditMu.Unlock()
+}
+
+// FinishAudit - writes the audit record with the exit status of the
+// command, a failure to write it is only a warning
+func (c *Config) FinishAudit(exitStatus int, err error) {
+	c.auditMu.Lock()
+	rec := c.audit
+	c.audit = nil
+	c.auditMu.Unlock()
+	if rec == nil {
+		return
+	}
+
+	rec.ExitStatus = exitStatus
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	path, perr := common.AuditLogPath()
+	if perr == nil {
+		perr = common.AppendAuditRecord(path, rec)
+	}
+	if perr != nil {
+		logrus.WithError(perr).Warn("Could not write the audit log")
+	}
+}
+
+// auditResponse - records what a mutating API call returned
+func (c *Config) auditResponse(method, path string, query url.Values, body []byte) {
+	c.auditMu.Lock()
+	defer c.auditMu.Unlock()
+	if c.audit == nil {
+		return
+	}
+	if len(c.audit.Database) == 0 {
+		c.audit.Database = query.Get("database-name")
+	}
+	var result interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		result = string(body)
+	}
+	c.audit.Results = append(c.audit.Results, result)
+}
+
+// auditHook - writes the audit record when a command exits via
+// logrus.Fatal
+type auditHook struct {
+	c *Config
+}
+
+// Levels - the hook only fires for fatal entries
+func (h *auditHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel}
+}
+
+// Fire - logrus exits with 1 after a fatal entry
+func (h *auditHook) Fire(entry *logrus.Entry) error {
+	msg := entry.Message
+	if err, ok := entry.Data[logrus.ErrorKey].(error); ok {
+		msg = msg + ": " + err.Error()
+	}
+	h.c.FinishAudit(1, auditError(msg))
+	return nil
+}
+
+// auditError - a fatal log message recorded as the error of a command
+type auditError string
+
+func (e auditError) Error() string {
+	return string(e)
+}
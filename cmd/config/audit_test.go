@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/splicemachine/splicectl/common"
+)
+
+func TestAuditRecord(t *testing.T) {
+	home := t.TempDir()
+	os.Mkdir(filepath.Join(home, ".splicectl"), 0700)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":true}`)
+	}))
+	defer srv.Close()
+
+	c := &Config{ApiServer: srv.URL}
+	c.StartAudit("pause", []string{"--database-name=splicedb"}, "", "dev")
+	if _, err := c.APIClient().Pause(context.TODO(), "splicedb", "maintenance"); err != nil {
+		t.Fatal(err)
+	}
+	c.FinishAudit(0, nil)
+	// only written once
+	c.FinishAudit(1, nil)
+
+	path, _ := common.AuditLogPath()
+	records, err := common.ReadAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+	rec := records[0]
+	if rec.Command != "pause" || rec.Environment != "dev" || rec.ExitStatus != 0 || len(rec.Results) != 1 {
+		t.Errorf("unexpected record %+v", rec)
+	}
+}
+
+func TestFinishAuditConcurrent(t *testing.T) {
+	home := t.TempDir()
+	os.Mkdir(filepath.Join(home, ".splicectl"), 0700)
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+	homedir.Reset()
+	defer homedir.Reset()
+
+	c := &Config{}
+	c.StartAudit("ui pause", nil, "splicedb", "dev")
+	done := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		go func() {
+			c.auditResponse("POST", "pause", nil, []byte(`{}`))
+			c.FinishAudit(0, nil)
+			done <- struct{}{}
+		}()
+	}
+	<-done
+	<-done
+
+	path, _ := common.AuditLogPath()
+	records, err := common.ReadAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// BulkCommand - a command that can act on every workspace picked by the
+// selectors of common.AddBulkFlags
+type BulkCommand struct {
+	// Verb - what is done, for the confirmation and messages, ie: pause
+	Verb string
+	// Skip - why a selected workspace is left alone, empty to act on it
+	Skip func(db objects.CMClusterInfo) string
+	// Run - acts on one workspace
+	Run func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error)
+	// Want - the state --wait waits for, --wait is not supported when empty
+	Want string
+	// LeaveFirst - see WaitForDatabase
+	LeaveFirst bool
+}
+
+// RunBulk - runs bc on the selected workspaces after showing them and
+// asking for confirmation, the results are output as a table and the
+// command exits non-zero when any workspace failed
+func (c *Config) RunBulk(bc BulkCommand, opts common.BulkOptions, wait common.WaitOptions) {
+	list, err := c.ListDatabases(context.TODO())
+	if err != nil {
+		c.FatalError(err, "Could not get a list of workspaces")
+	}
+	selected, err := opts.Select(list)
+	if err != nil {
+		c.FatalError(err, "Could not select the workspaces")
+	}
+	if len(selected) == 0 {
+		c.FatalError(fmt.Errorf("no workspaces match the selectors: %w", ErrNotFound), "Nothing to "+bc.Verb)
+	}
+
+	results := bulkPlan(bc, selected)
+	todo := len(results.Results) - results.Count(objects.BulkSkipped)
+	writeBulkSummary(os.Stderr, bc.Verb, results, todo)
+	if todo == 0 {
+		c.OutputData(results)
+		return
+	}
+	if !opts.Yes {
+		question := fmt.Sprintf("%s %d workspaces?", bc.Verb, todo)
+		run, err := c.PromptForConfirm(strings.ToUpper(question[:1])+question[1:], false)
+		if err != nil {
+			c.FatalError(err, "Cancelled, no workspace was changed")
+		}
+		if !run {
+			logrus.Fatal("Cancelled, no workspace was changed")
+		}
+	}
+
+	c.runBulk(bc, results, opts, wait, os.Stderr)
+	c.OutputData(results)
+
+	if failed := results.Count(objects.BulkFailed); failed > 0 {
+		first := ""
+		for _, r := range results.Results {
+			if r.Result == objects.BulkFailed {
+				first = fmt.Sprintf("%s: %s", r.Database, r.Detail)
+				break
+			}
+		}
+		c.FatalError(fmt.Errorf("%d of %d workspaces failed, the first was %s", failed, todo, first), "Not every selected workspace was changed")
+	}
+}
+
+// bulkPlan - a result for each selected workspace, those bc skips are
+// already final
+func bulkPlan(bc BulkCommand, selected []objects.CMClusterInfo) *objects.BulkResultList {
+	results := &objects.BulkResultList{Results: make([]objects.BulkResult, 0, len(selected))}
+	for _, db := range selected {
+		r := objects.BulkResult{
+			Database: db.DcosAppId,
+			Status:   db.Status,
+			Account:  db.Account.AccountName,
+			Result:   objects.BulkNotRun,
+		}
+		if bc.Skip != nil {
+			if reason := bc.Skip(db); len(reason) > 0 {
+				r.Result = objects.BulkSkipped
+				r.Detail = reason
+			}
+		}
+		results.Results = append(results.Results, r)
+	}
+	return results
+}
+
+// writeBulkSummary - the workspaces that will be acted on, and those that
+// are skipped with the reason
+func writeBulkSummary(w io.Writer, verb string, results *objects.BulkResultList, todo int) {
+	fmt.Fprintf(w, "%d workspaces selected, %d to %s:\n", len(results.Results), todo, verb)
+	for _, r := range results.Results {
+		line := fmt.Sprintf("  %-30s %-10s %s", r.Database, r.Status, r.Account)
+		if r.Result == objects.BulkSkipped {
+			line = fmt.Sprintf("%s (skipped: %s)", line, r.Detail)
+		}
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
+
+// runBulk - works on the results that are not run yet with at most
+// opts.MaxConcurrency at once, progress is written to w.  Without
+// opts.ContinueOnError no new workspace is started after a failure.
+func (c *Config) runBulk(bc BulkCommand, results *objects.BulkResultList, opts common.BulkOptions, wait common.WaitOptions, w io.Writer) {
+	var (
+		mu      sync.Mutex
+		stopped bool
+		done    int
+		wg      sync.WaitGroup
+	)
+	todo := len(results.Results) - results.Count(objects.BulkSkipped)
+	// a slot is taken before looking at stopped, so a failure is seen by
+	// every workspace that was not started yet
+	slots := make(chan struct{}, opts.MaxConcurrency)
+
+	for idx := range results.Results {
+		r := &results.Results[idx]
+		if r.Result != objects.BulkNotRun {
+			continue
+		}
+		slots <- struct{}{}
+		mu.Lock()
+		stop := stopped
+		mu.Unlock()
+		if stop {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			start := time.Now()
+			process, err := c.bulkOne(bc, r.Database, wait)
+
+			mu.Lock()
+			defer mu.Unlock()
+			r.Process = process
+			r.Duration = time.Since(start).Round(time.Second).String()
+			if err != nil {
+				r.Result = objects.BulkFailed
+				r.Detail = err.Error()
+				if !opts.ContinueOnError {
+					stopped = true
+				}
+			} else {
+				r.Result = objects.BulkDone
+			}
+			done++
+			fmt.Fprintf(w, "[%d/%d] %s %s\n", done, todo, r.Database, r.Result)
+		}()
+	}
+	wg.Wait()
+}
+
+// bulkOne - runs bc on one workspace and waits for it when asked to, a
+// request the API server did not accept is a failure
+func (c *Config) bulkOne(bc BulkCommand, database string, wait common.WaitOptions) (string, error) {
+	status, err := bc.Run(context.TODO(), c.APIClient(), database)
+	if err != nil {
+		return "", err
+	}
+	process := ""
+	if status != nil {
+		process = status.Process
+		if !status.Success && len(status.Error) > 0 {
+			return process, errors.New(status.Error)
+		}
+	}
+	if wait.Wait && len(bc.Want) > 0 {
+		if err := c.waitForDatabase(database, bc.Want, bc.LeaveFirst, wait, func(string) {}); err != nil {
+			return process, err
+		}
+	}
+	return process, nil
+}
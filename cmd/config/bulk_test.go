@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+func TestRunBulk(t *testing.T) {
+	selected := []objects.CMClusterInfo{
+		{DcosAppId: "a", Status: "Active"},
+		{DcosAppId: "b", Status: "Active"},
+		{DcosAppId: "c", Status: "Paused"},
+		{DcosAppId: "d", Status: "Active"},
+	}
+	bc := BulkCommand{
+		Verb: "pause",
+		Skip: func(db objects.CMClusterInfo) string {
+			if db.Status != StateActive {
+				return "not Active"
+			}
+			return ""
+		},
+		Run: func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error) {
+			if database == "b" {
+				return &objects.ActionStatus{Process: "pause", Error: "refused"}, nil
+			}
+			if database == "d" {
+				return nil, errors.New("unreachable")
+			}
+			return &objects.ActionStatus{Process: "pause", Success: true}, nil
+		},
+	}
+	results := func(opts common.BulkOptions) []string {
+		list := bulkPlan(bc, selected)
+		(&Config{}).runBulk(bc, list, opts, common.WaitOptions{}, ioutil.Discard)
+		out := []string{}
+		for _, r := range list.Results {
+			out = append(out, r.Database+" "+r.Result)
+		}
+		return out
+	}
+
+	got := results(common.BulkOptions{MaxConcurrency: 1})
+	want := []string{"a done", "b failed", "c skipped", "d not run"}
+	if len(got) != len(want) {
+		t.Fatalf("results = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("without --continue-on-error result %d = %s, expected %s", i, got[i], want[i])
+		}
+	}
+
+	got = results(common.BulkOptions{MaxConcurrency: 3, ContinueOnError: true})
+	if got[3] != "d failed" || got[1] != "b failed" || got[0] != "a done" {
+		t.Errorf("with --continue-on-error results = %v", got)
+	}
+}
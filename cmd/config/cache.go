@@ -0,0 +1,94 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// How long each lookup is reused, what can change under a running cluster
+// expires sooner
+const (
+	VersionTTL      = 10 * time.Minute
+	EnvironmentTTL  = time.Hour
+	IngressTTL      = time.Hour
+	DatabaseListTTL = 30 * time.Second
+)
+
+// DatabaseListKey - the cache key of the database list, commands that change
+// a workspace drop every key starting with it
+const DatabaseListKey = "databases"
+
+// invalidatedKeys - the keys a mutating API call makes stale, the vault
+// versions and workspaces offered by shell completion included
+var invalidatedKeys = []string{DatabaseListKey, "completion-"}
+
+// UseCache - opens the cache of the selected context, call after UseContext
+func (c *Config) UseCache() {
+	name := ""
+	if c.Context != nil {
+		name = c.Context.Name
+	}
+	cache, err := common.NewCache(name)
+	if err != nil {
+		logrus.WithError(err).Debug("The cache is not available")
+		return
+	}
+	c.Cache = cache
+}
+
+// CacheGet - decodes the cached value of key into v, false when there is
+// none, it has expired or --no-cache was given
+func (c *Config) CacheGet(key string, ttl time.Duration, v interface{}) bool {
+	if c.Cache == nil || c.NoCache {
+		return false
+	}
+	return c.Cache.Get(key, ttl, v)
+}
+
+// CachePut - caches v under key, with --no-cache fresh answers are still
+// stored for the commands that follow
+func (c *Config) CachePut(key string, v interface{}) {
+	if c.Cache == nil {
+		return
+	}
+	if err := c.Cache.Put(key, v); err != nil {
+		logrus.WithError(err).Debugf("Could not cache %s", key)
+	}
+}
+
+// InvalidateCache - drops what a change on the cluster makes stale
+func (c *Config) InvalidateCache() {
+	if c.Cache == nil {
+		return
+	}
+	if err := c.Cache.Invalidate(invalidatedKeys...); err != nil {
+		logrus.WithError(err).Warn("Could not invalidate the cache")
+	}
+}
+
+// databaseListKey - the database list is kept per API server
+func (c *Config) databaseListKey() string {
+	return DatabaseListKey + "-" + c.ApiServer
+}
+
+// ListDatabases - the database list from the API server, the cache is
+// refreshed with it
+func (c *Config) ListDatabases(ctx context.Context) (*objects.DatabaseList, error) {
+	list, _, err := c.ListDatabasesRaw(ctx)
+	return list, err
+}
+
+// ListDatabasesRaw - ListDatabases and the body as the server sent it
+func (c *Config) ListDatabasesRaw(ctx context.Context) (*objects.DatabaseList, json.RawMessage, error) {
+	list, raw, err := c.APIClient().ListDatabasesRaw(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.CachePut(c.databaseListKey(), list)
+	return list, raw, nil
+}
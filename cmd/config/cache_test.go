@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/splicemachine/splicectl/common"
+)
+
+func TestDatabaseListCache(t *testing.T) {
+	c := statusServer(t, "Active", "Pausing", "Paused")
+	c.Cache = &common.Cache{Dir: t.TempDir()}
+
+	status := func() string {
+		t.Helper()
+		database, err := c.GetDatabase("splicedb")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return database.Status
+	}
+
+	if got := status(); got != "Active" {
+		t.Fatalf("first lookup = %s, expected Active", got)
+	}
+	if got := status(); got != "Active" {
+		t.Errorf("second lookup = %s, expected the cached Active", got)
+	}
+	c.NoCache = true
+	if got := status(); got != "Pausing" {
+		t.Errorf("lookup with --no-cache = %s, expected Pausing", got)
+	}
+	c.NoCache = false
+	c.observeResponse("POST", "splicectl/v1/splicedb/pausedatabase", nil, []byte(`{}`))
+	if got := status(); got != "Paused" {
+		t.Errorf("lookup after a change = %s, expected Paused", got)
+	}
+}
@@ -1,16 +1,19 @@
 package config
 
 import (
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/splicemachine/splicectl/auth"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
 )
 
 type (
@@ -26,119 +29,90 @@ type (
 		AuthClient       auth.Client
 		CACert           string
 		CABundle         string
+		Context          *objects.NamedContext
+		Environment      string
+		NonInteractive   bool
+		NoCache          bool
+		Cache            *common.Cache
 
 		// tui functions
 		PromptForCSP          func() (string, error)
 		PromptForAccountID    func() (string, error)
 		PromptForDatabaseName func() (string, error)
+		PromptForConfirm      func(message string, defaultAnswer bool) (bool, error)
+
+		// audit record of the running command, when it changes something,
+		// bulk commands add the responses of several requests at once
+		audit   *objects.AuditRecord
+		auditMu sync.Mutex
 	}
-	// Outputable - defines ways that an object may need to present itself
-	Outputable interface {
-		ToJSON() string
-		ToYAML() string
-		ToGRON() string
-		ToText(noHeaders bool) string
-	}
+	// Outputable - anything the printers can render, the table columns are
+	// declared with struct tags, see printers.TableOf
+	Outputable interface{}
 )
 
-// GetDatabaseList - gets a list of databases
-func (c *Config) GetDatabaseList() ([]byte, error) {
-	uri := "splicectl/v1/splicedb/splicedatabase"
-	resp, resperr := c.
-		RestyWithHeaders().
-		Execute("LIST", fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Database List")
-		return []byte{}, resperr
+// APIClient - a client for the API server, authenticated with the current
+// session.
+func (c *Config) APIClient() *client.Client {
+	opts := []client.Option{client.WithCABundle(c.CABundle)}
+	if c.AuthClient != nil {
+		opts = append(opts, client.WithCredentials(c.AuthClient))
 	}
+	opts = append(opts, client.WithResponseHook(c.observeResponse))
+	return client.New(c.ApiServer, opts...)
+}
 
-	return resp.Body()[:], nil
-
+// observeResponse - a request changed something on the cluster, it is
+// recorded in the audit log and what was cached about it is dropped
+func (c *Config) observeResponse(method, path string, query url.Values, body []byte) {
+	c.InvalidateCache()
+	c.auditResponse(method, path, query, body)
 }
 
-// GetDatabaseListStruct - gets a list of databases, marshalled into struct
+// GetDatabaseListStruct - gets a list of databases, marshalled into struct,
+// a list cached within DatabaseListTTL is reused
 func (c *Config) GetDatabaseListStruct() (*objects.DatabaseList, error) {
-	data, err := c.GetDatabaseList()
-	if err != nil {
-		return nil, err
+	list := &objects.DatabaseList{}
+	if c.CacheGet(c.databaseListKey(), DatabaseListTTL, list) {
+		return list, nil
 	}
+	return c.ListDatabases(context.TODO())
+}
 
-	// filter and order DBList returned from api call
-	rawRespBody, dbList := data, &objects.DatabaseList{}
-	if err := json.Unmarshal(rawRespBody, dbList); err != nil {
+// GetDatabase - finds a workspace by name in the database list
+func (c *Config) GetDatabase(name string) (*objects.CMClusterInfo, error) {
+	list, err := c.GetDatabaseListStruct()
+	if err != nil {
 		return nil, err
 	}
-
-	return dbList, nil
+	return findDatabase(list, name)
 }
 
-// GetAccounts - get list of accounts
-func (c *Config) GetAccounts() (string, error) {
-	uri := "splicectl/v1/cm/accounts"
-	resp, resperr := c.
-		RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Account List Info")
-		return "", resperr
+// findDatabase - the workspace called name in list
+func findDatabase(list *objects.DatabaseList, name string) (*objects.CMClusterInfo, error) {
+	if database := list.Find(name); database != nil {
+		return database, nil
 	}
-
-	return string(resp.Body()[:]), nil
+	return nil, fmt.Errorf("no database matched given name '%s': %w", name, ErrNotFound)
 }
 
-func (c *Config) RestyClientWithCA() *resty.Client {
-	client := resty.New()
-	if len(c.CABundle) > 0 {
-		roots := x509.NewCertPool()
-		ok := roots.AppendCertsFromPEM([]byte(c.CABundle))
-		if !ok {
-			logrus.Info("Failed to parse CABundle")
-		}
-		client.SetTLSClientConfig(&tls.Config{RootCAs: roots})
-	}
-	return client
+// PrintOptions - the printer options set on the command line
+func (c *Config) PrintOptions() printers.Options {
+	return printers.Options{NoHeaders: c.NoHeaders, Wide: strings.ToLower(c.OutputFormat) == "wide"}
 }
 
-// GetVersionInfo - gets version information
-func (c *Config) GetVersionInfo() (string, error) {
-	uri := "splicectl"
-	resp, resperr := c.RestyClientWithCA().R().
-		SetHeader("Content-Type", "application/json").
-		SetHeader("Accept", "application/json").
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting version info")
-		return "", resperr
+// Render - the string representation of data in accordance with
+// OutputFormat
+func (c *Config) Render(data Outputable) string {
+	out, err := printers.Print(c.OutputFormat, data, c.PrintOptions())
+	if errors.Is(err, printers.ErrNoWideColumns) {
+		c.FatalError(err, "Invalid output format")
 	}
-
-	return strings.TrimSuffix(string(resp.Body()[:]), "\n"), nil
-}
-
-// RestyWithHeaders - new resty request with headers for auth and content-type.
-func (c *Config) RestyWithHeaders() *resty.Request {
-	return c.RestyClientWithCA().R().
-		SetHeader("Content-Type", "application/json").
-		SetHeader("Accept", "application/json").
-		SetHeader("X-Token-Bearer", c.AuthClient.GetTokenBearer()).
-		SetHeader("X-Token-Session", c.AuthClient.GetSessionID())
-}
-
-func (c *Config) outputData(data Outputable) string {
-	switch strings.ToLower(c.OutputFormat) {
-	case "json":
-		return data.ToJSON()
-	case "gron":
-		return data.ToGRON()
-	case "yaml":
-		return data.ToYAML()
-	case "text", "table":
-		return data.ToText(c.NoHeaders)
-	default:
+	if err != nil {
+		logrus.WithError(err).Error("Error rendering output")
 		return ""
 	}
+	return out
 }
 
 // OutputData - outputs string representation of data in accordance with
@@ -148,5 +122,15 @@ func (c *Config) OutputData(data Outputable) {
 		c.OutputFormat = "text"
 	}
 
-	fmt.Println(c.outputData(data))
+	fmt.Println(c.Render(data))
+}
+
+// OutputRaw - outputs data like OutputData, except -o raw prints raw, the
+// body exactly as the server sent it
+func (c *Config) OutputRaw(data Outputable, raw []byte) {
+	if strings.ToLower(c.OutputFormat) == "raw" {
+		fmt.Println(string(raw))
+		return
+	}
+	c.OutputData(data)
 }
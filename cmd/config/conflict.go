@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// CheckExpectedVersion - fails with ErrVersionConflict when the latest
+// version of r is not expected, the latest version is returned either way.
+// The API server has no compare-and-set for Vault, so this narrows the window
+// for two operators overwriting each other rather than closing it.
+func (c *Config) CheckExpectedVersion(r client.VaultResource, expected int) (int, error) {
+	latest, err := c.LatestVersion(r)
+	if err != nil {
+		return 0, err
+	}
+	if latest != expected {
+		return latest, fmt.Errorf("%s is at version %d, expected version %d: %w", r, latest, expected, ErrVersionConflict)
+	}
+	return latest, nil
+}
+
+// ThreeWayDiff - the changes made to r since version base, by the versions
+// stored since (theirs) and by doc (ours), with the paths both changed
+func (c *Config) ThreeWayDiff(r client.VaultResource, base, latest int, doc []byte, source string) (*objects.ThreeWayDiff, error) {
+	var baseDoc, theirDoc []byte
+	var err error
+	if base > 0 {
+		if baseDoc, err = c.APIClient().GetVaultDocument(context.TODO(), r, base); err != nil {
+			return nil, err
+		}
+	}
+	if latest > 0 {
+		if theirDoc, err = c.APIClient().GetVaultDocument(context.TODO(), r, latest); err != nil {
+			return nil, err
+		}
+	}
+
+	theirs, err := common.DiffJSON(baseDoc, theirDoc)
+	if err != nil {
+		return nil, err
+	}
+	ours, err := common.DiffJSON(baseDoc, doc)
+	if err != nil {
+		return nil, err
+	}
+	_, conflicts, err := common.MergeJSON(baseDoc, doc, theirDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	baseName := fmt.Sprintf("%s version %d", r, base)
+	theirs.From, theirs.To = baseName, fmt.Sprintf("%s version %d (latest)", r, latest)
+	ours.From, ours.To = baseName, source
+	return &objects.ThreeWayDiff{
+		Base:      baseName,
+		Theirs:    theirs,
+		Ours:      ours,
+		Conflicts: conflicts,
+	}, nil
+}
+
+// EnforceExpectedVersion - implements --expected-version for the apply
+// commands, exits with the conflict exit code when the version moved after
+// printing the three-way diff if --show-conflict was given.
+func (c *Config) EnforceExpectedVersion(r client.VaultResource, check common.VersionCheck, doc []byte, source string) {
+	if !check.Set {
+		return
+	}
+	latest, err := c.CheckExpectedVersion(r, check.Expected)
+	if err == nil {
+		return
+	}
+	if latest != 0 && check.ShowConflict {
+		if diff, derr := c.ThreeWayDiff(r, check.Expected, latest, doc, source); derr != nil {
+			fmt.Fprintf(os.Stderr, "Could not compare the versions: %v\n", derr)
+		} else {
+			c.OutputData(diff)
+		}
+	}
+	c.FatalError(err, "Refusing to apply")
+}
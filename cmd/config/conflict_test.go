@@ -0,0 +1,66 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/splicemachine/splicectl/client"
+)
+
+// vaultServer - serves the default CR with docs as versions 1..n
+func vaultServer(t *testing.T, docs ...string) *Config {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/splicectl/v1/vault/defaultcrversions":
+			fmt.Fprint(w, "{")
+			for i := range docs {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `"%d":{"created_time":"","deletion_time":"","destroyed":false}`, i+1)
+			}
+			fmt.Fprint(w, "}")
+		case "/splicectl/v1/vault/defaultcr":
+			var version int
+			fmt.Sscan(r.URL.Query().Get("version"), &version)
+			if version == 0 {
+				version = len(docs)
+			}
+			fmt.Fprint(w, docs[version-1])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return &Config{ApiServer: srv.URL}
+}
+
+func TestCheckExpectedVersion(t *testing.T) {
+	c := vaultServer(t, `{"data":{"a":1}}`, `{"data":{"a":2}}`)
+
+	if latest, err := c.CheckExpectedVersion(client.DefaultCR(), 2); err != nil || latest != 2 {
+		t.Fatalf("expected version 2 to be the latest, got %d %v", latest, err)
+	}
+	if _, err := c.CheckExpectedVersion(client.DefaultCR(), 1); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+}
+
+func TestThreeWayDiff(t *testing.T) {
+	c := vaultServer(t, `{"data":{"a":1,"b":1}}`, `{"data":{"a":2,"b":1}}`)
+
+	diff, err := c.ThreeWayDiff(client.DefaultCR(), 1, 2, []byte(`{"data":{"a":3,"b":2}}`), "file.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Theirs.Changes) != 1 || len(diff.Ours.Changes) != 2 {
+		t.Errorf("expected 1 change stored since and 2 of ours, got %+v %+v", diff.Theirs.Changes, diff.Ours.Changes)
+	}
+	if !reflect.DeepEqual(diff.Conflicts, []string{".data.a"}) {
+		t.Errorf("expected .data.a to conflict, got %v", diff.Conflicts)
+	}
+}
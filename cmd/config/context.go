@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+const (
+	currentContextKey = "current-context"
+	contextsKey       = "contexts"
+)
+
+// GetContextList - reads the named contexts from the config file
+func GetContextList() (*objects.ContextList, error) {
+	list := &objects.ContextList{
+		Current:  viper.GetString(currentContextKey),
+		Contexts: make([]objects.NamedContext, 0),
+	}
+	if err := viper.UnmarshalKey(contextsKey, &list.Contexts); err != nil {
+		return nil, fmt.Errorf("%v; could not read the contexts from the config file", err)
+	}
+	return list, nil
+}
+
+// SaveContextList - writes the named contexts back to the config file
+func SaveContextList(list *objects.ContextList) error {
+	viper.Set(currentContextKey, list.Current)
+	viper.Set(contextsKey, list.Contexts)
+	return viper.WriteConfig()
+}
+
+// FindContext - returns the index of the named context, or -1
+func FindContext(list *objects.ContextList, name string) int {
+	for i, v := range list.Contexts {
+		if v.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// UseContext - selects the named context for this invocation, an empty name
+// selects the current-context from the config file.  When no contexts have
+// been defined splicectl falls back to the kubeconfig current context.
+func (c *Config) UseContext(name string) error {
+	list, err := GetContextList()
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		name = list.Current
+	}
+	if len(name) == 0 {
+		return nil
+	}
+
+	idx := FindContext(list, name)
+	if idx < 0 {
+		return fmt.Errorf("context '%s' does not exist, see 'splicectl context list'", name)
+	}
+	c.Context = &list.Contexts[idx]
+
+	common.Target.KubeConfig = c.Context.KubeConfig
+	common.Target.KubeContext = c.Context.KubeContext
+	if len(c.Context.Namespace) > 0 {
+		common.Target.Namespace = c.Context.Namespace
+	}
+	if len(c.Context.Ingress) > 0 {
+		common.Target.Ingress = c.Context.Ingress
+	}
+	return nil
+}
+
+// SessionFor - the stored session for the active context, sessions saved
+// before contexts existed were keyed by environment name and are still used
+// until the context gets a session of its own.
+func (c *Config) SessionFor(environment string) common.SessionData {
+	if c.Context != nil && len(c.Context.Session.SessionID) > 0 {
+		return common.SessionData(c.Context.Session)
+	}
+	return common.SessionData{
+		SessionID:  viper.GetString(fmt.Sprintf("%s-session_id", environment)),
+		ValidUntil: viper.GetString(fmt.Sprintf("%s-valid_until", environment)),
+	}
+}
+
+// SaveSession - stores a new session on the active context, or under the
+// environment name when no context is in use.
+func (c *Config) SaveSession(environment string, sess common.SessionData) error {
+	if c.Context == nil {
+		viper.Set(fmt.Sprintf("%s-session_id", environment), sess.SessionID)
+		viper.Set(fmt.Sprintf("%s-valid_until", environment), sess.ValidUntil)
+		return viper.WriteConfig()
+	}
+
+	list, err := GetContextList()
+	if err != nil {
+		return err
+	}
+	idx := FindContext(list, c.Context.Name)
+	if idx < 0 {
+		return fmt.Errorf("context '%s' does not exist", c.Context.Name)
+	}
+	list.Contexts[idx].Session = objects.ContextSession(sess)
+	c.Context.Session = list.Contexts[idx].Session
+	return SaveContextList(list)
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// VaultDiff - the changes storing doc would make to the latest version of
+// the resource, a resource that does not exist yet is diffed against an
+// empty document.  The version compared against is recorded so it can be
+// passed to 'apply --expected-version'.
+func (c *Config) VaultDiff(r client.VaultResource, doc []byte, source string) (*objects.DiffChangeList, error) {
+	latest, err := c.LatestVersion(r)
+	if err != nil {
+		return nil, err
+	}
+	var current []byte
+	if latest > 0 {
+		if current, err = c.APIClient().GetVaultDocument(context.TODO(), r, latest); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := common.DiffJSON(current, doc)
+	if err != nil {
+		return nil, err
+	}
+	changes.From = fmt.Sprintf("%s version %d (latest)", r, latest)
+	changes.To = source
+	changes.FromVersion = latest
+	return changes, nil
+}
+
+// ShowVaultDiff - prints the changes storing doc would make, for --dry-run
+// and 'splicectl diff'.  The text form is the default.
+func (c *Config) ShowVaultDiff(r client.VaultResource, doc []byte, source string) *objects.DiffChangeList {
+	changes, err := c.VaultDiff(r, doc, source)
+	if err != nil {
+		c.FatalError(err, "Error comparing with the current version")
+	}
+	c.OutputData(changes)
+	return changes
+}
+
+// VersionDiff - the changes between two versions of the resource
+func (c *Config) VersionDiff(r client.VaultResource, from, to int) (*objects.DiffChangeList, error) {
+	fromDoc, err := c.APIClient().GetVaultDocument(context.TODO(), r, from)
+	if err != nil {
+		return nil, err
+	}
+	toDoc, err := c.APIClient().GetVaultDocument(context.TODO(), r, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := common.DiffJSON(fromDoc, toDoc)
+	if err != nil {
+		return nil, err
+	}
+	changes.From = fmt.Sprintf("%s version %d", r, from)
+	changes.To = fmt.Sprintf("%s version %d", r, to)
+	return changes, nil
+}
+
+// ShowVersionDiff - prints the changes between the versions named by spec,
+// for 'versions <kind> --diff 3..5'
+func (c *Config) ShowVersionDiff(r client.VaultResource, versions *objects.VaultVersionList, spec string) {
+	from, to, err := common.ResolveVersionRange(spec, versions.Latest())
+	if err != nil {
+		logrus.WithError(err).Fatal("Invalid --diff")
+	}
+	changes, err := c.VersionDiff(r, from, to)
+	if err != nil {
+		c.FatalError(err, "Error comparing versions")
+	}
+	c.OutputData(changes)
+}
+
+// LatestVersion - the newest version of the resource, 0 when it has not been
+// stored yet
+func (c *Config) LatestVersion(r client.VaultResource) (int, error) {
+	versions, err := c.APIClient().ListVersions(context.TODO(), r)
+	if err != nil {
+		if ExitCode(err) == ExitNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return versions.Latest(), nil
+}
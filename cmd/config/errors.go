@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/printers"
+)
+
+// Exit codes, scripts can branch on the class of failure
+const (
+	ExitGeneral  = 1
+	ExitUsage    = 2
+	ExitAuth     = 3
+	ExitNotFound = 4
+	ExitConflict = 5
+	ExitServer   = 6
+	ExitNetwork  = 7
+	ExitTimeout  = 8
+	ExitFailed   = 9
+	// ExitChanges - diff --exit-code found differences, not a failure
+	ExitChanges = 10
+)
+
+var (
+	// ErrNotFound - the named object does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrSessionExpired - the auth session needs to be renewed with 'auth'
+	ErrSessionExpired = errors.New("your session has expired, please run 'splicectl auth' again")
+	// ErrVersionConflict - the document changed in Vault since it was read
+	ErrVersionConflict = errors.New("the version in Vault has changed")
+)
+
+// MissingInputError - required inputs were not given on the command line
+// and can not be prompted for, Inputs are the flags that supply them
+type MissingInputError struct {
+	Inputs []string
+}
+
+func (e *MissingInputError) Error() string {
+	return fmt.Sprintf("missing required input, supply %s (prompts are disabled by --non-interactive or when stdin is not a terminal)", strings.Join(e.Inputs, "; "))
+}
+
+// errorClasses - the names of the exit codes, used when rendering errors
+var errorClasses = map[int]string{
+	ExitGeneral:  "general",
+	ExitUsage:    "usage",
+	ExitAuth:     "auth",
+	ExitNotFound: "not-found",
+	ExitConflict: "conflict",
+	ExitServer:   "server",
+	ExitNetwork:  "network",
+	ExitTimeout:  "timeout",
+	ExitFailed:   "failed",
+}
+
+// ExitCode - the exit code for the class of failure err belongs to
+func ExitCode(err error) int {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			return ExitAuth
+		case apiErr.StatusCode == http.StatusNotFound:
+			return ExitNotFound
+		case apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed:
+			return ExitConflict
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return ExitServer
+		}
+		return ExitGeneral
+	}
+
+	var netErr *client.NetworkError
+	var opErr net.Error
+	var missingErr *MissingInputError
+	switch {
+	case errors.As(err, &missingErr), errors.Is(err, printers.ErrNoWideColumns):
+		return ExitUsage
+	case errors.Is(err, ErrSessionExpired):
+		return ExitAuth
+	case errors.Is(err, ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, ErrVersionConflict):
+		return ExitConflict
+	case errors.Is(err, ErrWaitTimeout):
+		return ExitTimeout
+	case errors.Is(err, ErrWaitFailed):
+		return ExitFailed
+	case errors.As(err, &netErr), errors.As(err, &opErr):
+		return ExitNetwork
+	}
+	return ExitGeneral
+}
+
+// errorDetail - the structured form of err
+func errorDetail(err error, msg string) *objects.ErrorDetail {
+	code := ExitCode(err)
+	detail := &objects.ErrorDetail{
+		Error:    fmt.Sprintf("%s: %v", msg, err),
+		Class:    errorClasses[code],
+		ExitCode: code,
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		detail.Status = apiErr.StatusCode
+		detail.Message = apiErr.Message
+		detail.RequestID = apiErr.RequestID
+		detail.Method = apiErr.Method
+		detail.Path = apiErr.Path
+	}
+	var netErr *client.NetworkError
+	if errors.As(err, &netErr) {
+		detail.Method = netErr.Method
+		detail.Path = netErr.Path
+	}
+	return detail
+}
+
+// FatalError - reports err and exits with the exit code of its class, the
+// error is rendered as an object when -o json, yaml or gron was requested.
+func (c *Config) FatalError(err error, msg string) {
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+	var missingErr *MissingInputError
+	if errors.As(err, &missingErr) {
+		// the message of the caller is about what it was going to do
+		msg = "Required input was not provided"
+	}
+	detail := errorDetail(err, msg)
+	c.FinishAudit(detail.ExitCode, fmt.Errorf("%s: %w", msg, err))
+
+	switch strings.ToLower(c.OutputFormat) {
+	case "json", "yaml", "gron":
+		if c.FormatOverridden {
+			fmt.Fprintln(os.Stderr, strings.TrimSpace(c.Render(detail)))
+			os.Exit(detail.ExitCode)
+		}
+	}
+	logrus.WithError(err).Error(msg)
+	os.Exit(detail.ExitCode)
+}
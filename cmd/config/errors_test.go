@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/splicemachine/splicectl/client"
+)
+
+func TestExitCode(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{&client.APIError{StatusCode: 401}, ExitAuth},
+		{&client.APIError{StatusCode: 403}, ExitAuth},
+		{&client.APIError{StatusCode: 404}, ExitNotFound},
+		{&client.APIError{StatusCode: 409}, ExitConflict},
+		{&client.APIError{StatusCode: 503}, ExitServer},
+		{&client.APIError{StatusCode: 400}, ExitGeneral},
+		{&client.NetworkError{Err: errors.New("connection refused")}, ExitNetwork},
+		{fmt.Errorf("no database matched given name 'x': %w", ErrNotFound), ExitNotFound},
+		{ErrSessionExpired, ExitAuth},
+		{fmt.Errorf("default-cr is now at version 5: %w", ErrVersionConflict), ExitConflict},
+		{fmt.Errorf("wrapped: %w", &MissingInputError{Inputs: []string{"--database-name"}}), ExitUsage},
+		{errors.New("anything else"), ExitGeneral},
+	} {
+		if got := ExitCode(tc.err); got != tc.want {
+			t.Errorf("ExitCode(%v) = %d, expected %d", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// Workspace states --wait can wait for
+const (
+	StateActive = "Active"
+	StatePaused = "Paused"
+	// StateGone - the workspace is no longer listed
+	StateGone = ""
+)
+
+var (
+	// ErrWaitTimeout - the workspace did not reach the state in time
+	ErrWaitTimeout = errors.New("timed out waiting for the workspace")
+	// ErrWaitFailed - the workspace reported a failure state while waiting
+	ErrWaitFailed = errors.New("workspace entered a failure state")
+)
+
+// failedState - statuses Cloud Manager uses when an operation did not work
+func failedState(status string) bool {
+	s := strings.ToLower(status)
+	return strings.Contains(s, "fail") || strings.Contains(s, "error")
+}
+
+// WaitForDatabase - polls the workspace list until name reports the state
+// want, StateGone waits for the workspace to disappear.  When leaveFirst is
+// set the workspace has to be seen outside of want before it counts, for a
+// restart of an Active workspace, but only for the first few polls so a
+// quick restart is not missed.
+func (c *Config) WaitForDatabase(name, want string, leaveFirst bool, opts common.WaitOptions) error {
+	target := want
+	if want == StateGone {
+		target = "removed"
+	}
+	spinner := common.NewSpinner(os.Stderr, fmt.Sprintf("Waiting for %s to be %s:", name, target))
+	defer spinner.Stop()
+	return c.waitForDatabase(name, want, leaveFirst, opts, spinner.Update)
+}
+
+// waitForDatabase - WaitForDatabase, each status seen is passed to progress
+func (c *Config) waitForDatabase(name, want string, leaveFirst bool, opts common.WaitOptions, progress func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	status := "unknown"
+	for polls := 1; ; polls++ {
+		var database *objects.CMClusterInfo
+		list, err := c.ListDatabases(ctx)
+		if err == nil {
+			database, err = findDatabase(list, name)
+		}
+		switch {
+		case err == nil:
+			status = database.Status
+		case errors.Is(err, ErrNotFound):
+			status = StateGone
+		case ctx.Err() == nil && ExitCode(err) == ExitNetwork:
+			// the API server may be restarting too, keep trying
+		case ctx.Err() == nil:
+			return err
+		}
+
+		if status == StateGone {
+			progress("not listed")
+		} else {
+			progress(status)
+		}
+
+		if leaveFirst && (status != want || polls > 3) {
+			leaveFirst = false
+		}
+		if !leaveFirst && strings.EqualFold(status, want) {
+			return nil
+		}
+		if failedState(status) {
+			return fmt.Errorf("%s reported status '%s': %w", name, status, ErrWaitFailed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s still '%s' after %s: %w", name, status, opts.Timeout, ErrWaitTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitAfterAction - implements --wait for commands that submit an action
+// against a workspace, exits non-zero when the action was refused, the
+// workspace reports a failure state or the timeout passes.
+func (c *Config) WaitAfterAction(status *objects.ActionStatus, name, want string, leaveFirst bool, opts common.WaitOptions) {
+	if !opts.Wait {
+		return
+	}
+	if status != nil && !status.Success && len(status.Error) > 0 {
+		c.FatalError(fmt.Errorf("%s: %w", status.Error, ErrWaitFailed), "The request was not accepted")
+	}
+	if err := c.WaitForDatabase(name, want, leaveFirst, opts); err != nil {
+		c.FatalError(err, "Error waiting for the workspace")
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/splicemachine/splicectl/common"
+)
+
+// statusServer - reports the workspace splicedb with each of statuses in
+// turn, repeating the last one, an empty status leaves it out of the list.
+func statusServer(t *testing.T, statuses ...string) *Config {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+		if len(status) == 0 {
+			fmt.Fprint(w, `{"clusters":[]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"clusters":[{"dcosAppId":"splicedb","status":"%s"}]}`, status)
+	}))
+	t.Cleanup(srv.Close)
+	return &Config{ApiServer: srv.URL}
+}
+
+func TestWaitForDatabase(t *testing.T) {
+	opts := common.WaitOptions{Wait: true, Timeout: time.Second, PollInterval: time.Millisecond}
+
+	if err := statusServer(t, "Pausing", "Pausing", "Paused").WaitForDatabase("splicedb", StatePaused, false, opts); err != nil {
+		t.Fatalf("expected the workspace to pause, got %v", err)
+	}
+	if err := statusServer(t, "Active", "Deleting", "").WaitForDatabase("splicedb", StateGone, false, opts); err != nil {
+		t.Fatalf("expected the workspace to be removed, got %v", err)
+	}
+	if err := statusServer(t, "Active", "Restarting", "Active").WaitForDatabase("splicedb", StateActive, true, opts); err != nil {
+		t.Fatalf("expected the workspace to restart, got %v", err)
+	}
+	if err := statusServer(t, "Resuming", "Failed").WaitForDatabase("splicedb", StateActive, false, opts); !errors.Is(err, ErrWaitFailed) {
+		t.Fatalf("expected a failure state, got %v", err)
+	}
+
+	opts.Timeout = 20 * time.Millisecond
+	if err := statusServer(t, "Pausing").WaitForDatabase("splicedb", StatePaused, false, opts); !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
+)
+
+// watchHighlight - how long a changed cell stays highlighted
+const watchHighlight = time.Minute
+
+// watchedRow - the recent changes of a row of a watched table
+type watchedRow struct {
+	at      time.Time
+	added   bool
+	changes map[string]objects.WatchChange
+}
+
+// Watch - polls fetch every interval until interrupted.  On a terminal the
+// table is redrawn in place, with the cells that changed in the last minute
+// shown as old→new, otherwise each added, changed or removed row is written
+// as a JSON line.  A failed poll is reported and retried.
+func (c *Config) Watch(ctx context.Context, title string, interval time.Duration, fetch func(context.Context) (Outputable, error)) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	if !c.FormatOverridden {
+		c.OutputFormat = "text"
+	}
+	opts := c.PrintOptions()
+	redraw := common.IsTerminal(os.Stdout) && c.tableFormat()
+	enc := json.NewEncoder(os.Stdout)
+
+	var prev *printers.Table
+	rows := map[string]*watchedRow{}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		data, err := fetch(ctx)
+		var table *printers.Table
+		if err == nil {
+			table, err = common.WatchTable(data, opts)
+		}
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil && redraw:
+			c.redrawWatch(title, interval, now, prev, rows, err)
+		case err != nil:
+			logrus.WithError(err).Warn("Poll failed, retrying")
+		default:
+			events := common.DiffTables(prev, table, now)
+			if redraw {
+				trackChanges(rows, events, prev == nil, now)
+				c.redrawWatch(title, interval, now, table, rows, nil)
+			} else {
+				for _, event := range events {
+					enc.Encode(event)
+				}
+			}
+			prev = table
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tableFormat - whether the output format is one of the text tables
+func (c *Config) tableFormat() bool {
+	switch strings.ToLower(c.OutputFormat) {
+	case "text", "table", "wide":
+		return true
+	}
+	return false
+}
+
+// trackChanges - records the events of a poll and forgets the changes that
+// are no longer highlighted
+func trackChanges(rows map[string]*watchedRow, events []objects.WatchEvent, first bool, now time.Time) {
+	for key, row := range rows {
+		if now.Sub(row.at) > watchHighlight {
+			delete(rows, key)
+		}
+	}
+	if first {
+		return
+	}
+	for _, event := range events {
+		if event.Type == objects.WatchDeleted {
+			continue
+		}
+		row, ok := rows[event.Key]
+		if !ok {
+			row = &watchedRow{changes: map[string]objects.WatchChange{}}
+			rows[event.Key] = row
+		}
+		row.at = now
+		row.added = row.added || event.Type == objects.WatchAdded
+		for header, change := range event.Changes {
+			if earlier, ok := row.changes[header]; ok {
+				change.Old = earlier.Old
+			}
+			row.changes[header] = change
+		}
+	}
+}
+
+// redrawWatch - clears the terminal and draws the table with the recent
+// changes highlighted
+func (c *Config) redrawWatch(title string, interval time.Duration, now time.Time, table *printers.Table, rows map[string]*watchedRow, pollErr error) {
+	fmt.Fprint(os.Stdout, "\033[H\033[2J")
+	fmt.Fprintln(os.Stdout, color.New(color.Bold).Sprintf("Every %s: %s", interval, title), now.Format(time.RFC3339))
+	fmt.Fprintln(os.Stdout)
+	if pollErr != nil {
+		fmt.Fprintln(os.Stdout, color.RedString("Poll failed, retrying: %v", pollErr))
+		fmt.Fprintln(os.Stdout)
+	}
+	if table == nil {
+		return
+	}
+
+	shown := &printers.Table{Header: table.Header, Footer: table.Footer}
+	for i, key := range common.TableKeys(table) {
+		row := append([]string{}, table.Rows[i]...)
+		if watched, ok := rows[key]; ok {
+			for j := range row {
+				if j >= len(table.Header) {
+					continue
+				}
+				if change, ok := watched.changes[table.Header[j]]; ok {
+					row[j] = color.New(color.FgYellow, color.Bold).Sprintf("%s→%s", change.Old, change.New)
+				}
+			}
+			if watched.added && len(row) > 0 {
+				row[0] = color.GreenString(row[0])
+			}
+		}
+		shown.Append(row...)
+	}
+	fmt.Fprint(os.Stdout, shown.Render(c.NoHeaders))
+}
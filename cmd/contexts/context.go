@@ -0,0 +1,30 @@
+package contexts
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+)
+
+var contextCmd = &cobra.Command{
+	Use:     "context",
+	Aliases: []string{"contexts", "ctx"},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Manage named contexts for multiple clusters/environments",
+	Long: `EXAMPLES
+	splicectl context add dev --kube-context dev-cluster
+	splicectl context add staging --kubeconfig ~/.kube/staging --server-uri https://splicectl.staging.example.com
+	splicectl context use dev
+	splicectl context list
+	splicectl --context staging list workspace
+
+	Each context keeps its own auth session, run 'splicectl auth' once per context.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return contextCmd
+}
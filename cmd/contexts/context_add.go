@@ -0,0 +1,99 @@
+package contexts
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+var addContextCmd = &cobra.Command{
+	Use:   "add <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Add a named context to the config file.",
+	Long: `EXAMPLES
+	splicectl context add dev --kube-context dev-cluster
+	splicectl context add customer-a --kubeconfig ~/.kube/customer-a --cacert ~/certs/customer-a.crt
+	splicectl context add lab --server-uri https://splicectl.lab.example.com --namespace splice-lab --use
+
+	Any setting not supplied falls back to the usual default: the KUBECONFIG
+	environment variable or ~/.kube/config, its current context, and the
+	splicectl-api ingress in the splice-system namespace.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := config.GetContextList()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading contexts")
+		}
+		if config.FindContext(list, args[0]) >= 0 {
+			logrus.Fatalf("Context '%s' already exists, delete it first to replace it", args[0])
+		}
+
+		nc := objects.NamedContext{Name: args[0]}
+		nc.KubeConfig, _ = cmd.Flags().GetString("kubeconfig")
+		nc.KubeContext, _ = cmd.Flags().GetString("kube-context")
+		nc.ServerURI, _ = cmd.Flags().GetString("server-uri")
+		nc.CACert, _ = cmd.Flags().GetString("cacert")
+		nc.Namespace, _ = cmd.Flags().GetString("namespace")
+		nc.Ingress, _ = cmd.Flags().GetString("ingress")
+		if len(nc.CACert) > 0 {
+			if _, err := os.Stat(nc.CACert); err != nil {
+				logrus.WithError(err).Fatal("Couldn't read the ca-file, please check the path")
+			}
+		}
+
+		list.Contexts = append(list.Contexts, nc)
+		if use, _ := cmd.Flags().GetBool("use"); use || len(list.Current) == 0 {
+			list.Current = nc.Name
+		}
+		if err := config.SaveContextList(list); err != nil {
+			logrus.WithError(err).Fatal("Failed to write config")
+		}
+		fmt.Printf("Context \"%s\" added.\n", nc.Name)
+	},
+}
+
+var deleteContextCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Args:    cobra.ExactArgs(1),
+	Short:   "Remove a named context, and its session, from the config file.",
+	Long: `EXAMPLES
+	splicectl context delete customer-a
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := config.GetContextList()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading contexts")
+		}
+		idx := config.FindContext(list, args[0])
+		if idx < 0 {
+			logrus.Fatalf("Context '%s' does not exist", args[0])
+		}
+
+		list.Contexts = append(list.Contexts[:idx], list.Contexts[idx+1:]...)
+		if list.Current == args[0] {
+			list.Current = ""
+		}
+		if err := config.SaveContextList(list); err != nil {
+			logrus.WithError(err).Fatal("Failed to write config")
+		}
+		fmt.Printf("Context \"%s\" deleted.\n", args[0])
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(addContextCmd)
+	contextCmd.AddCommand(deleteContextCmd)
+
+	addContextCmd.Flags().String("kubeconfig", "", "Path to the kubeconfig file for the cluster")
+	addContextCmd.Flags().String("kube-context", "", "Name of the context inside the kubeconfig file")
+	addContextCmd.Flags().String("server-uri", "", "The splicectl API server uri, skips the ingress lookup")
+	addContextCmd.Flags().String("cacert", "", "A cacert file to use to authenticate the SSL certificate")
+	addContextCmd.Flags().String("namespace", "", "Namespace holding the splicectl API (default splice-system)")
+	addContextCmd.Flags().String("ingress", "", "Name of the splicectl API ingress (default splicectl-api)")
+	addContextCmd.Flags().Bool("use", false, "Make the new context the current context")
+}
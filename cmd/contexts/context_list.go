@@ -0,0 +1,29 @@
+package contexts
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+)
+
+var listContextCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the named contexts in the config file.",
+	Long: `EXAMPLES
+	splicectl context list
+	splicectl context list -o yaml
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := config.GetContextList()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading contexts")
+		}
+
+		c.OutputData(list)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(listContextCmd)
+}
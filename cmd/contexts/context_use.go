@@ -0,0 +1,57 @@
+package contexts
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+)
+
+var useContextCmd = &cobra.Command{
+	Use:   "use <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Make a named context the current context.",
+	Long: `EXAMPLES
+	splicectl context use staging
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := config.GetContextList()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading contexts")
+		}
+		if config.FindContext(list, args[0]) < 0 {
+			logrus.Fatalf("Context '%s' does not exist", args[0])
+		}
+
+		list.Current = args[0]
+		if err := config.SaveContextList(list); err != nil {
+			logrus.WithError(err).Fatal("Failed to write config")
+		}
+		fmt.Printf("Switched to context \"%s\".\n", args[0])
+	},
+}
+
+var currentContextCmd = &cobra.Command{
+	Use:   "current",
+	Args:  cobra.NoArgs,
+	Short: "Show the current context.",
+	Long: `EXAMPLES
+	splicectl context current
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := config.GetContextList()
+		if err != nil {
+			logrus.WithError(err).Fatal("Error reading contexts")
+		}
+		if len(list.Current) == 0 {
+			logrus.Fatal("No current context is set, the kubeconfig current context is used")
+		}
+		fmt.Println(list.Current)
+	},
+}
+
+func init() {
+	contextCmd.AddCommand(useContextCmd)
+	contextCmd.AddCommand(currentContextCmd)
+}
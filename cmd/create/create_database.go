@@ -1,17 +1,20 @@
 package create
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
-	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
 
 	"github.com/spf13/cobra"
 )
@@ -30,6 +33,9 @@ var createDatabaseCmd = &cobra.Command{
 	splicectl create workspace --skel --account-id <accountid> --cloud-provider <aws|az|gcp|op|none> > ~/tmp/splicedb-create.yaml
 	# edit the ~/tmp/splicedb-create.yaml
 	splicectl create workspace --file ~/tmp/splicedb-create.yaml
+
+	# block until the workspace is Active, exits non-zero on timeout or failure
+	splicectl create workspace --file ~/tmp/splicedb-create.yaml --wait --timeout 45m
 	
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -71,9 +77,9 @@ var createDatabaseCmd = &cobra.Command{
 			os.Exit(0)
 		}
 
-		out, err := createSpliceDatabase(&dbReq, false)
+		out, err := c.APIClient().CreateDatabase(context.TODO(), &dbReq)
 		if err != nil {
-			logrus.WithError(err).Error("Error Generating Default CR Info")
+			c.FatalError(err, "Error creating workspace")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.7"); err != nil {
@@ -83,12 +89,16 @@ var createDatabaseCmd = &cobra.Command{
 				displayCreateSpliceDatabaseV1(out)
 			}
 		}
+		waitFor := dbReq.Name
+		if out != nil && len(out.Database) > 0 {
+			waitFor = out.Database
+		}
+		c.WaitAfterAction(out, waitFor, config.StateActive, false, common.WaitFlags(cmd))
 	},
 }
 
-func displayCreateSpliceDatabaseV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayCreateSpliceDatabaseV1(status *objects.ActionStatus) {
+	c.OutputData(status)
 }
 
 func populateRequest(cmd *cobra.Command, req *objects.DatabaseRequest, fileData bool) {
@@ -192,21 +202,16 @@ func populateRequest(cmd *cobra.Command, req *objects.DatabaseRequest, fileData
 	req.CloudProvider = strings.ToUpper(req.CloudProvider)
 
 	if len(requiredList) > 0 {
-		for _, v := range requiredList {
-			logrus.Warn(fmt.Sprintf("Required parameter not provided: %s", v))
-		}
-		os.Exit(1)
+		c.FatalError(&config.MissingInputError{Inputs: requiredList}, "Required parameters not provided")
 	}
 }
 
 func dbReqToString(dbReq *objects.DatabaseRequest) string {
 	switch strings.ToLower(c.OutputFormat) {
 	case "json", "gron":
-		return dbReq.ToJSON()
-	case "yaml", "text", "table":
-		return dbReq.ToYAML()
+		return printers.ToJSON(dbReq)
 	default:
-		return dbReq.ToYAML()
+		return printers.ToYAML(dbReq)
 	}
 }
 
@@ -216,40 +221,12 @@ func generateSkel(dbReq *objects.DatabaseRequest) {
 	}
 	fmt.Println(dbReqToString(dbReq))
 }
-func createSpliceDatabase(dbReq *objects.DatabaseRequest, outputonly bool) (string, error) {
-	uri := "splicectl/v1/splicedb/splicedatabase"
-
-	var resp *resty.Response
-	var resperr error
-
-	if outputonly {
-		resp, resperr = c.RestyWithHeaders().
-			Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-	} else {
-
-		reqJSON, enverr := json.MarshalIndent(dbReq, "", "  ")
-		if enverr != nil {
-			logrus.WithError(enverr).Error("Error extracting json")
-			return "", enverr
-		}
-		resp, resperr = c.RestyWithHeaders().
-			SetBody(reqJSON).
-			Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-	}
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
-}
-
 func init() {
 	createCmd.AddCommand(createDatabaseCmd)
 
 	createDatabaseCmd.Flags().BoolP("skel", "s", false, "Generate a skeleton values file for submission")
 	createDatabaseCmd.Flags().StringP("file", "f", "", "Specify the input file")
+	common.AddWaitFlags(createDatabaseCmd, 30*time.Minute)
 
 	// add database name and aliases
 	createDatabaseCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
@@ -1,9 +1,8 @@
 package del
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
+	"context"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
@@ -20,6 +19,7 @@ var deleteCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl list workspace
 	splicectl delete --database-name <database> --delete
+	splicectl delete --database-name <database> --delete --wait
 
 	* The '--delete' is required as a validation for the deletion request
 	  
@@ -39,15 +39,15 @@ var deleteCmd = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of workspaces", dberr)
+				c.FatalError(dberr, "Could not get a list of workspaces")
 			}
 		}
 		if verifyDelete {
 			clusterID := getMatchingClusterID(databaseName)
 			if len(clusterID) > 0 {
-				out, err := deleteDatabase(clusterID)
+				out, err := c.APIClient().DeleteDatabase(context.TODO(), clusterID)
 				if err != nil {
-					logrus.Warn("Deleting workspace failed.")
+					c.FatalError(err, "Deleting workspace failed.")
 				}
 				if semverV1, err := semver.ParseRange(">=0.1.7"); err != nil {
 					logrus.Fatal("Failed to parse SemVer")
@@ -56,6 +56,7 @@ var deleteCmd = &cobra.Command{
 						displayDeleteV1(out)
 					}
 				}
+				c.WaitAfterAction(out, databaseName, config.StateGone, false, common.WaitFlags(cmd))
 			} else {
 				logrus.Fatal("Unable to determine ClusterId from workspace Name")
 			}
@@ -65,43 +66,17 @@ var deleteCmd = &cobra.Command{
 	},
 }
 
-func displayDeleteV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayDeleteV1(status *objects.ActionStatus) {
+	c.OutputData(status)
 }
 
 func getMatchingClusterID(db string) string {
-	dbJSON, err := c.GetDatabaseList()
+	database, err := c.GetDatabase(db)
 	if err != nil {
-		logrus.WithError(err).Fatal("Error retreiving ClusterId list")
+		logrus.WithError(err).Error("Error retreiving ClusterId list")
+		return ""
 	}
-	var dbList objects.DatabaseList
-
-	marshErr := json.Unmarshal([]byte(dbJSON), &dbList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall workspace list for ClusterId", marshErr)
-	}
-
-	for _, v := range dbList.Clusters {
-		if v.DcosAppId == db {
-			return v.ClusterId
-		}
-	}
-
-	return ""
-}
-
-func deleteDatabase(cid string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/splicedb/splicedatabasedelete?database-name=%s", cid)
-	resp, resperr := c.RestyWithHeaders().
-		Delete(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+	return database.ClusterId
 }
 
 var c *config.Config
@@ -118,4 +93,5 @@ func init() {
 	deleteCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
 	deleteCmd.Flags().Bool("delete", false, "Verification parameter to perform the deletion")
+	common.AddWaitFlags(deleteCmd, 10*time.Minute)
 }
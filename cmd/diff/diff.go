@@ -0,0 +1,64 @@
+package diff
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/common"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Show the changes a file would make to a vault backed resource",
+	Long: `EXAMPLES
+	splicectl get system-settings -o json > ~/tmp/system-settings.json
+	# edit the file
+	splicectl diff system-settings --file ~/tmp/system-settings.json
+	splicectl diff system-settings --file ~/tmp/system-settings.json -o json
+
+	The diff ignores key order, so YAML and JSON files can be compared with the
+	version stored in Vault.  Use --exit-code to exit 10 when there are changes,
+	failures exit with the codes listed in the README.
+	The version compared against can be passed to 'apply --expected-version' so
+	the file is only applied if nobody stored a newer version in the meantime.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// runDiff - diff the file given with --file against the latest version of r
+func runDiff(cmd *cobra.Command, r client.VaultResource) {
+	filePath, _ := cmd.Flags().GetString("file")
+	fileBytes, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Could not read the input file")
+	}
+
+	jsonBytes, cerr := common.WantJSON(fileBytes)
+	if cerr != nil {
+		logrus.Fatal("The input data MUST be in either JSON or YAML format")
+	}
+
+	changes := c.ShowVaultDiff(r, jsonBytes, filePath)
+	if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode && changes.HasChanges() {
+		os.Exit(config.ExitChanges)
+	}
+}
+
+// addDiffFlags - flags shared by each kind
+func addDiffFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "Specify the input file")
+	cmd.Flags().Bool("exit-code", false, "Exit with 10 when there are differences, 0 otherwise")
+	cmd.MarkFlagRequired("file")
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return diffCmd
+}
@@ -0,0 +1,35 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var diffCMSettingsCmd = &cobra.Command{
+	Use:   "cm-settings",
+	Short: "Show the changes a file would make to the cm (cloud manager) settings",
+	Long: `EXAMPLES
+	splicectl diff cm-settings --component ui --file ~/tmp/cm-ui.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("diff_cm-settings")
+
+		component, _ := cmd.Flags().GetString("component")
+		component = strings.ToLower(component)
+		if len(component) == 0 || !strings.Contains("ui api", component) {
+			logrus.Fatal("--component needs to be 'ui' or 'api'")
+		}
+
+		runDiff(cmd, client.CMSettings(component))
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffCMSettingsCmd)
+
+	diffCMSettingsCmd.Flags().StringP("component", "c", "", "Specify the component, <ui|api>")
+	addDiffFlags(diffCMSettingsCmd)
+}
@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/common"
+)
+
+var diffDatabaseCRCmd = &cobra.Command{
+	Use:   "database-cr",
+	Short: "Show the changes a file would make to the CR of a database",
+	Long: `EXAMPLES
+	splicectl diff database-cr --database-name splicedb --file ~/tmp/splicedb.json
+
+	Note: --database-name and -d are the preferred way to supply the database name.
+	However, --database and --workspace can also be used as well. In the event that
+	more than one of them is supplied database-name and d are preferred over all
+	and workspace is preferred over database. The most preferred option that is
+	supplied will be used and a message will be displayed letting you know which
+	option was chosen if more than one were supplied.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var dberr error
+		c.VersionDetail.RequirementMet("diff_database-cr")
+
+		databaseName := common.DatabaseName(cmd)
+		if len(databaseName) == 0 {
+			databaseName, dberr = c.PromptForDatabaseName()
+			if dberr != nil {
+				c.FatalError(dberr, "Could not get a list of Databases")
+			}
+		}
+
+		runDiff(cmd, client.DatabaseCR(databaseName))
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffDatabaseCRCmd)
+
+	// add database name and aliases
+	diffDatabaseCRCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
+	diffDatabaseCRCmd.Flags().String("database", "", "Alias for database-name, prefer the use of -d and --database-name.")
+	diffDatabaseCRCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
+
+	addDiffFlags(diffDatabaseCRCmd)
+}
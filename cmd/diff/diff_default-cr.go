@@ -0,0 +1,25 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var diffDefaultCRCmd = &cobra.Command{
+	Use:   "default-cr",
+	Short: "Show the changes a file would make to the default CR",
+	Long: `EXAMPLES
+	splicectl diff default-cr --file ~/tmp/default-cr.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("diff_default-cr")
+
+		runDiff(cmd, client.DefaultCR())
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffDefaultCRCmd)
+
+	addDiffFlags(diffDefaultCRCmd)
+}
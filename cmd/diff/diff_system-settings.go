@@ -0,0 +1,25 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var diffSystemSettingsCmd = &cobra.Command{
+	Use:   "system-settings",
+	Short: "Show the changes a file would make to the system settings",
+	Long: `EXAMPLES
+	splicectl diff system-settings --file ~/tmp/system-settings.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("diff_system-settings")
+
+		runDiff(cmd, client.SystemSettings())
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffSystemSettingsCmd)
+
+	addDiffFlags(diffSystemSettingsCmd)
+}
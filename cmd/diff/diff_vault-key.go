@@ -0,0 +1,34 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var diffVaultKeyCmd = &cobra.Command{
+	Use:   "vault-key",
+	Short: "Show the changes a file would make to a specific vault key",
+	Long: `EXAMPLES
+	splicectl diff vault-key --keypath services/cloudmanager/config/default/ui --file ~/tmp/cm-ui.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("diff_vault-key")
+
+		keyPath, _ := cmd.Flags().GetString("keypath")
+		if strings.HasPrefix(keyPath, "secrets/") {
+			keyPath = strings.TrimPrefix(keyPath, "secrets/")
+		}
+
+		runDiff(cmd, client.VaultKey(keyPath))
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffVaultKeyCmd)
+
+	diffVaultKeyCmd.Flags().String("keypath", "", "Specify the vault key path")
+	diffVaultKeyCmd.MarkFlagRequired("keypath")
+	addDiffFlags(diffVaultKeyCmd)
+}
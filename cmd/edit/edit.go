@@ -0,0 +1,212 @@
+package edit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	"sigs.k8s.io/yaml"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Edit a vault backed resource in your editor and apply the changes",
+	Long: `EXAMPLES
+	splicectl edit system-settings
+	EDITOR="code --wait" splicectl edit default-cr -o json
+
+	The latest version is opened in $SPLICECTL_EDITOR, $EDITOR or $VISUAL as
+	YAML, or JSON with -o json.  When the editor exits the document is
+	validated, the changes are shown and applied once confirmed.  If someone
+	else stored a new version while you were editing, the edit is refused
+	unless you choose to merge the changes, see --merge.
+	`,
+	Run: func(cmd *cobra.Command, args []string) {},
+}
+
+// runEdit - the edit, validate, diff, confirm and apply cycle for r
+func runEdit(cmd *cobra.Command, r client.VaultResource) {
+	ctx := context.TODO()
+	assumeYes, _ := cmd.Flags().GetBool("yes")
+	merge, _ := cmd.Flags().GetBool("merge")
+
+	baseVersion, err := c.LatestVersion(r)
+	if err != nil {
+		c.FatalError(err, fmt.Sprintf("Error getting the versions of %s", r))
+	}
+	original := []byte("{}")
+	if baseVersion > 0 {
+		if original, err = c.APIClient().GetVaultDocument(ctx, r, baseVersion); err != nil {
+			c.FatalError(err, fmt.Sprintf("Error getting %s", r))
+		}
+	}
+
+	asJSON := c.FormatOverridden && strings.EqualFold(c.OutputFormat, "json")
+	content, err := editorContent(original, asJSON)
+	if err != nil {
+		logrus.WithError(err).Fatal("Could not prepare the document for editing")
+	}
+
+	suffix := ".yaml"
+	if asJSON {
+		suffix = ".json"
+	}
+	tmpFile, err := ioutil.TempFile("", "splicectl-edit-*"+suffix)
+	if err != nil {
+		logrus.WithError(err).Fatal("Could not create a temporary file")
+	}
+	tmpFile.Close()
+	tmpPath := tmpFile.Name()
+
+	var edited []byte
+	for {
+		if err := ioutil.WriteFile(tmpPath, content, 0600); err != nil {
+			logrus.WithError(err).Fatal("Could not write the temporary file")
+		}
+		if err := common.EditFile(tmpPath); err != nil {
+			logrus.WithError(err).Fatalf("Edit cancelled, your changes are in %s", tmpPath)
+		}
+		saved, err := ioutil.ReadFile(tmpPath)
+		if err != nil {
+			logrus.WithError(err).Fatal("Could not read the edited file")
+		}
+		if len(bytes.TrimSpace(saved)) == 0 {
+			os.Remove(tmpPath)
+			fmt.Fprintln(os.Stderr, "Edit cancelled, the file was emptied")
+			return
+		}
+
+		edited, err = common.WantJSON(saved)
+		if err == nil {
+			err = client.ValidateVaultDocument(r, edited)
+		}
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "The edited document is not valid: %v\n", err)
+		reopen, perr := c.PromptForConfirm("Re-open the editor?", true)
+		if perr != nil || !reopen {
+			logrus.Fatalf("Edit cancelled, your changes are in %s", tmpPath)
+		}
+		content = saved
+	}
+
+	changes, err := common.DiffJSON(original, edited)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error comparing the edited document")
+	}
+	if !changes.HasChanges() {
+		os.Remove(tmpPath)
+		fmt.Fprintln(os.Stderr, "Edit cancelled, no changes made")
+		return
+	}
+	changes.From = fmt.Sprintf("%s version %d", r, baseVersion)
+	changes.To = "edited"
+	if !confirmChanges(changes, r, assumeYes) {
+		logrus.Fatalf("Edit cancelled, your changes are in %s", tmpPath)
+	}
+
+	// someone may have stored a version while the editor was open
+	latestVersion, err := c.CheckExpectedVersion(r, baseVersion)
+	if err != nil && !errors.Is(err, config.ErrVersionConflict) {
+		c.FatalError(err, fmt.Sprintf("Error getting the versions of %s", r))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s changed from version %d to %d while you were editing\n", r, baseVersion, latestVersion)
+		if !merge && !assumeYes {
+			merge, _ = c.PromptForConfirm("Merge your changes with the new version?", false)
+		}
+		if !merge {
+			c.FatalError(fmt.Errorf("%w, your changes are in %s", err, tmpPath), "Refusing to apply")
+		}
+		edited = mergeEdit(r, original, edited, latestVersion, tmpPath, assumeYes)
+	}
+
+	out, err := c.APIClient().ApplyVaultDocument(ctx, r, edited)
+	if err != nil {
+		c.FatalError(err, fmt.Sprintf("Error applying %s, your changes are in %s", r, tmpPath))
+	}
+	os.Remove(tmpPath)
+	fmt.Print(c.Render(out))
+}
+
+// mergeEdit - three-way merge of the edit with the version stored since the
+// editor was opened, exits when the same paths were changed on both sides
+func mergeEdit(r client.VaultResource, original, edited []byte, latestVersion int, tmpPath string, assumeYes bool) []byte {
+	current, err := c.APIClient().GetVaultDocument(context.TODO(), r, latestVersion)
+	if err != nil {
+		c.FatalError(err, fmt.Sprintf("Error getting %s", r))
+	}
+	merged, conflicts, err := common.MergeJSON(original, edited, current)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error merging the changes")
+	}
+	if len(conflicts) > 0 {
+		for _, path := range conflicts {
+			fmt.Fprintf(os.Stderr, "conflict: %s\n", path)
+		}
+		c.FatalError(fmt.Errorf("%d paths were changed in version %d as well, your changes are in %s: %w", len(conflicts), latestVersion, tmpPath, config.ErrVersionConflict), "Could not merge")
+	}
+	if err := client.ValidateVaultDocument(r, merged); err != nil {
+		logrus.WithError(err).Fatalf("The merged document is not valid, your changes are in %s", tmpPath)
+	}
+
+	changes, err := common.DiffJSON(current, merged)
+	if err != nil {
+		logrus.WithError(err).Fatal("Error comparing the merged document")
+	}
+	changes.From = fmt.Sprintf("%s version %d", r, latestVersion)
+	changes.To = "merged"
+	if !confirmChanges(changes, r, assumeYes) {
+		logrus.Fatalf("Edit cancelled, your changes are in %s", tmpPath)
+	}
+	return merged
+}
+
+// confirmChanges - shows the changes and asks before they are applied
+func confirmChanges(changes *objects.DiffChangeList, r client.VaultResource, assumeYes bool) bool {
+	fmt.Print(changes.ToText(c.NoHeaders))
+	if assumeYes {
+		return true
+	}
+	apply, err := c.PromptForConfirm(fmt.Sprintf("Apply these changes to %s?", r), false)
+	return err == nil && apply
+}
+
+// editorContent - the document as it is presented in the editor
+func editorContent(doc []byte, asJSON bool) ([]byte, error) {
+	if asJSON {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, doc, "", "  "); err != nil {
+			return nil, err
+		}
+		indented.WriteString("\n")
+		return indented.Bytes(), nil
+	}
+	return yaml.JSONToYAML(doc)
+}
+
+// addEditFlags - flags shared by each kind
+func addEditFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking for confirmation")
+	cmd.Flags().Bool("merge", false, "Merge with a version stored while editing, without asking")
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return editCmd
+}
@@ -0,0 +1,35 @@
+package edit
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var editCMSettingsCmd = &cobra.Command{
+	Use:   "cm-settings",
+	Short: "Edit the cm (cloud manager) settings",
+	Long: `EXAMPLES
+	splicectl edit cm-settings --component ui
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("edit_cm-settings")
+
+		component, _ := cmd.Flags().GetString("component")
+		component = strings.ToLower(component)
+		if len(component) == 0 || !strings.Contains("ui api", component) {
+			logrus.Fatal("--component needs to be 'ui' or 'api'")
+		}
+
+		runEdit(cmd, client.CMSettings(component))
+	},
+}
+
+func init() {
+	editCmd.AddCommand(editCMSettingsCmd)
+
+	editCMSettingsCmd.Flags().StringP("component", "c", "", "Specify the component, <ui|api>")
+	addEditFlags(editCMSettingsCmd)
+}
@@ -0,0 +1,25 @@
+package edit
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var editDefaultCRCmd = &cobra.Command{
+	Use:   "default-cr",
+	Short: "Edit the default CR",
+	Long: `EXAMPLES
+	splicectl edit default-cr
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("edit_default-cr")
+
+		runEdit(cmd, client.DefaultCR())
+	},
+}
+
+func init() {
+	editCmd.AddCommand(editDefaultCRCmd)
+
+	addEditFlags(editDefaultCRCmd)
+}
@@ -0,0 +1,25 @@
+package edit
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var editSystemSettingsCmd = &cobra.Command{
+	Use:   "system-settings",
+	Short: "Edit the system settings",
+	Long: `EXAMPLES
+	splicectl edit system-settings
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("edit_system-settings")
+
+		runEdit(cmd, client.SystemSettings())
+	},
+}
+
+func init() {
+	editCmd.AddCommand(editSystemSettingsCmd)
+
+	addEditFlags(editSystemSettingsCmd)
+}
@@ -0,0 +1,34 @@
+package edit
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+)
+
+var editVaultKeyCmd = &cobra.Command{
+	Use:   "vault-key",
+	Short: "Edit a specific vault key",
+	Long: `EXAMPLES
+	splicectl edit vault-key --keypath services/cloudmanager/config/default/ui
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("edit_vault-key")
+
+		keyPath, _ := cmd.Flags().GetString("keypath")
+		if strings.HasPrefix(keyPath, "secrets/") {
+			keyPath = strings.TrimPrefix(keyPath, "secrets/")
+		}
+
+		runEdit(cmd, client.VaultKey(keyPath))
+	},
+}
+
+func init() {
+	editCmd.AddCommand(editVaultKeyCmd)
+
+	editVaultKeyCmd.Flags().String("keypath", "", "Specify the vault key path")
+	editVaultKeyCmd.MarkFlagRequired("keypath")
+	addEditFlags(editVaultKeyCmd)
+}
@@ -5,43 +5,82 @@ import (
 	"fmt"
 	"os"
 
-	homedir "github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/common"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
+// getEnvironmentName - the environment of the cluster, cached for
+// config.EnvironmentTTL once it was read from the vault-key-store secret.
+// It is not cached when the cluster can't be told apart from others.
 func getEnvironmentName() string {
+	cluster := common.KubeClusterKey()
+	if len(cluster) == 0 {
+		environment, _ := readEnvironmentName()
+		return environment
+	}
+	key := fmt.Sprintf("environment-%s-%s", cluster, common.Target.Namespace)
+	environment := ""
+	if c.CacheGet(key, config.EnvironmentTTL, &environment) {
+		return environment
+	}
+	environment, ok := readEnvironmentName()
+	if ok {
+		c.CachePut(key, environment)
+	}
+	return environment
+}
 
-	cfg, err := restConfig()
+// readEnvironmentName - false when the 'default' environment is a fallback
+// rather than the answer of the cluster
+func readEnvironmentName() (string, bool) {
+	cfg, err := common.RestConfig()
 	if err != nil {
 		logrus.WithError(err).Fatal("could not get config")
 		os.Exit(1)
 	}
 	if cfg == nil {
-		return "default"
+		return "default", false
 	}
 
 	client, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		logrus.WithError(err).Fatal("could not create client from config")
-		return "default"
+		return "default", false
 	}
 
-	secretResource, secerr := client.CoreV1().Secrets("splice-system").Get(context.TODO(), "vault-key-store", v1.GetOptions{})
+	secretResource, secerr := client.CoreV1().Secrets(common.Target.Namespace).Get(context.TODO(), "vault-key-store", v1.GetOptions{})
 	if secerr != nil {
 		logrus.WithError(secerr).Error("Secret Not Found vault-key-store")
-		return "default"
+		return "default", false
 	}
 
-	return string(secretResource.Data["ENVIRONMENT"][:])
+	return string(secretResource.Data["ENVIRONMENT"][:]), true
 
 }
 
+// getIngressDetail - the API server behind the ingress, cached for
+// config.IngressTTL once it was found, like getEnvironmentName
 func getIngressDetail() string {
-	cfg, err := restConfig()
+	cluster := common.KubeClusterKey()
+	if len(cluster) == 0 {
+		return readIngressDetail()
+	}
+	key := fmt.Sprintf("ingress-%s-%s-%s", cluster, common.Target.Namespace, common.Target.Ingress)
+	server := ""
+	if c.CacheGet(key, config.IngressTTL, &server) {
+		return server
+	}
+	if server = readIngressDetail(); len(server) > 0 {
+		c.CachePut(key, server)
+	}
+	return server
+}
+
+func readIngressDetail() string {
+	cfg, err := common.RestConfig()
 	if err != nil {
 		logrus.WithError(err).Fatal("could not get config")
 		os.Exit(1)
@@ -56,46 +95,11 @@ func getIngressDetail() string {
 		os.Exit(1)
 	}
 
-	ingressResult, err := client.NetworkingV1().Ingresses("splice-system").Get(context.TODO(), "splicectl-api", v1.GetOptions{})
+	ingressResult, err := client.NetworkingV1().Ingresses(common.Target.Namespace).Get(context.TODO(), common.Target.Ingress, v1.GetOptions{})
 	if err != nil {
-		logrus.WithError(err).Warn("could not read from ingress: splicectl-api")
+		logrus.WithError(err).Warnf("could not read from ingress: %s", common.Target.Ingress)
 		return ""
 	}
 	return fmt.Sprintf("https://%s", ingressResult.Spec.Rules[0].Host)
 
 }
-
-func restConfig() (*rest.Config, error) {
-	// We aren't likely to run this INSIDE the K8s cluster, this routine
-	// simply picks up the config from the file system of a running POD.
-	// kubeCfg, err := rest.InClusterConfig()
-	var kubeCfg *rest.Config
-	var err error
-
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			logrus.Info("No KUBECONFIG ENV")
-			return nil, err
-		}
-	} else {
-		// ENV KUBECONFIG not set, check for ~/.kube/config
-		home, err := homedir.Dir()
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		kubeFile := fmt.Sprintf("%s/%s", home, ".kube/config")
-		if _, err := os.Stat(kubeFile); err != nil {
-			if os.IsNotExist(err) {
-				if os.Args[1] != "version" {
-					logrus.Info("Could not locate the KUBECONFIG file, normally ~/.kube/config")
-					os.Exit(1)
-				}
-				return nil, nil
-			}
-		}
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeFile)
-	}
-	return kubeCfg, nil
-}
@@ -1,10 +1,8 @@
 package get
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
@@ -23,35 +21,23 @@ var getAccountsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("get_accounts")
 
-		out, err := c.GetAccounts()
+		out, raw, err := c.APIClient().ListAccountsRaw(context.TODO())
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error getting Accounts")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.7"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayGetAccountsV1(out)
+				displayGetAccountsV1(out, raw)
 			}
 		}
 	},
 }
 
-func displayGetAccountsV1(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-
-	var accounts objects.AccountList
-
-	marshErr := json.Unmarshal([]byte(in), &accounts)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&accounts)
+func displayGetAccountsV1(accounts *objects.AccountList, raw json.RawMessage) {
+	c.OutputRaw(accounts, raw)
 }
 
 func init() {
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 )
 
@@ -27,67 +29,33 @@ var getCMSettingsCmd = &cobra.Command{
 		if len(component) == 0 || !strings.Contains("ui api", component) {
 			logrus.Fatal("--component needs to be 'ui' or 'api'")
 		}
-		out, err := getCMSettings(component, version)
+		out := &objects.CMSettings{}
+		raw, err := c.APIClient().GetVaultRaw(context.TODO(), client.CMSettings(component), version, out)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting CM Settings")
+			c.FatalError(err, "Error getting CM Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayGetCmSettingsV1(out)
+				displayGetCmSettingsV1(out, raw)
 			}
 		}
 	},
 }
 
-func sessDataToString(sessData objects.CMSettings) string {
-	switch strings.ToLower(c.OutputFormat) {
-	case "json":
-		return sessData.ToJSON()
-	case "gron":
-		return sessData.ToGRON()
-	case "yaml":
-		return sessData.ToYAML()
-	case "text", "table":
-		return sessData.ToText(c.NoHeaders)
-	default:
-		return sessData.ToText(c.NoHeaders)
-	}
-}
-
-func displayGetCmSettingsV1(in string) {
+func displayGetCmSettingsV1(sessData *objects.CMSettings, raw json.RawMessage) {
 	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
+		fmt.Println(string(raw))
 		os.Exit(0)
 	}
 
-	var sessData objects.CMSettings
-	marshErr := json.Unmarshal([]byte(in), &sessData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
 	if !c.FormatOverridden {
 		c.OutputFormat = "yaml"
 	}
 
-	fmt.Println(sessDataToString(sessData))
-}
-
-func getCMSettings(comp string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/cmsettings?component=%s&version=%d", comp, ver)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+	fmt.Println(c.Render(sessData))
 }
 
 func init() {
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -36,22 +38,23 @@ var getDatabaseCRCmd = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of Databases", dberr)
+				c.FatalError(dberr, "Could not get a list of Databases")
 			}
 		}
 		filePath, _ := cmd.Flags().GetString("file")
 		version, _ := cmd.Flags().GetInt("version")
 
-		out, err := getDatabaseCR(databaseName, version)
+		out := &objects.DatabaseCR{}
+		raw, err := c.APIClient().GetVaultRaw(context.TODO(), client.DatabaseCR(databaseName), version, out)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting workspace CR Info")
+			c.FatalError(err, "Error getting workspace CR Info")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayGetDatabaseV1(out, filePath)
+				displayGetDatabaseV1(raw, filePath)
 			}
 		}
 
@@ -59,64 +62,43 @@ var getDatabaseCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayGetDatabaseV2(out, filePath)
+				displayGetDatabaseV2(out, raw, filePath)
 			}
 		}
 	},
 }
 
-func displayGetDatabaseV1(in string, fp string) {
-	if len(fp) == 0 {
-		fmt.Println(in)
-	} else {
-		objects.WriteToFile(fp, in)
-	}
-	os.Exit(0)
+// displayGetDatabaseV1 - these servers only had the document as it is stored
+func displayGetDatabaseV1(raw json.RawMessage, fp string) {
+	writeDatabaseCR(string(raw), fp)
 }
 
-func dbCRToString(dbCR objects.DatabaseCR, fp string) {
+// writeDatabaseCR - prints the CR, or writes it to fp when the output is
+// json or yaml
+func writeDatabaseCR(out string, fp string) {
 	switch strings.ToLower(c.OutputFormat) {
-	case "json":
-		dbCR.ToJSON(fp)
-	case "gron":
-		dbCR.ToGRON(fp)
-	case "yaml":
-		dbCR.ToYAML(fp)
-	case "text", "table":
-		dbCR.ToTEXT(c.NoHeaders)
+	case "json", "yaml", "raw":
+		if len(fp) > 0 {
+			if err := objects.WriteToFile(fp, out); err != nil {
+				logrus.WithError(err).Fatal("Error writing the database CR")
+			}
+			return
+		}
 	}
+	fmt.Println(out)
 }
 
-func displayGetDatabaseV2(in string, fp string) {
+func displayGetDatabaseV2(dbCR *objects.DatabaseCR, raw json.RawMessage, fp string) {
 	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
+		writeDatabaseCR(string(raw), fp)
 		os.Exit(0)
 	}
-	var dbCR objects.DatabaseCR
-	marshErr := json.Unmarshal([]byte(in), &dbCR)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
 
 	if !c.FormatOverridden {
 		c.OutputFormat = "yaml"
 	}
 
-	dbCRToString(dbCR, fp)
-}
-
-func getDatabaseCR(dbname string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/databasecr?version=%d&database-name=%s", ver, dbname)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting workspace CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+	writeDatabaseCR(c.Render(dbCR), fp)
 }
 
 func init() {
@@ -1,11 +1,12 @@
 package get
 
 import (
-	"fmt"
-	"os"
+	"context"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
 	"github.com/spf13/cobra"
@@ -16,6 +17,7 @@ var getDatabaseStatus = &cobra.Command{
 	Short: "Get the status of database.",
 	Long: `EXAMPLES
 	splicectl get database-status --database-name "test"
+	splicectl get database-status --database-name "test" --watch
 
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -32,13 +34,20 @@ var getDatabaseStatus = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get name of Database", dberr)
+				c.FatalError(dberr, "Could not get name of Database")
 			}
 		}
 
-		out, err := getDatabaseStatusData(databaseName)
+		if watch, interval := common.WatchFlags(cmd); watch {
+			c.Watch(context.Background(), cmd.CommandPath()+" -d "+databaseName, interval, func(ctx context.Context) (config.Outputable, error) {
+				return c.APIClient().GetDatabaseStatus(ctx, databaseName)
+			})
+			return
+		}
+
+		out, err := c.APIClient().GetDatabaseStatus(context.TODO(), databaseName)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting status of database ")
+			c.FatalError(err, "Error getting status of database ")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
@@ -51,21 +60,12 @@ var getDatabaseStatus = &cobra.Command{
 	},
 }
 
-func displayGetDatabaseStatusV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func getDatabaseStatusData(databaseName string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/splicedb/splicedatabasestatus?database-name=%s", databaseName)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Database status info")
-		return "", resperr
+func displayGetDatabaseStatusV1(status objects.DatabaseStatus) {
+	if !c.FormatOverridden {
+		c.OutputFormat = "json"
+		c.FormatOverridden = true
 	}
-	return string(resp.Body()[:]), nil
+	c.OutputData(status)
 }
 
 func init() {
@@ -75,4 +75,5 @@ func init() {
 	getDatabaseStatus.Flags().StringP("database-name", "d", "", "Specify the database name")
 	getDatabaseStatus.Flags().String("database", "", "Alias for database-name, prefer the use of -d and --database-name.")
 	getDatabaseStatus.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
+	common.AddWatchFlags(getDatabaseStatus)
 }
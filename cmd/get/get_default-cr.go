@@ -1,7 +1,7 @@
 package get
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,10 +9,10 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/printers"
 
-	"github.com/maahsome/gron"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 )
 
 var getDefaultCRCmd = &cobra.Command{
@@ -25,10 +25,11 @@ var getDefaultCRCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("get_default-cr")
 
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := getDefaultCR(version)
+		data, err := c.APIClient().GetVaultDocument(context.TODO(), client.DefaultCR(), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error getting Default CR Info")
 		}
+		out := string(data)
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
@@ -74,41 +75,15 @@ func displayGetDefaultCRV2(in string) {
 	switch strings.ToLower(c.OutputFormat) {
 	case "json":
 		fmt.Println(string(in[:]))
-	case "gron":
-		subReader := strings.NewReader(string(in[:]))
-		subValues := &bytes.Buffer{}
-		ges := gron.NewGron(subReader, subValues)
-		ges.SetMonochrome(false)
-		serr := ges.ToGron()
-		if serr != nil {
-			logrus.Error("Problem generating gron syntax", serr)
-		} else {
-			fmt.Println(string(subValues.Bytes()))
-		}
-	case "yaml", "text", "table":
-		rawDefaultCr, crerr := yaml.Marshal(defaultCr)
-		if crerr != nil {
-			logrus.WithError(crerr).Error("Failed to convert to YAML")
-		}
-		fmt.Println(string(rawDefaultCr[:]))
+	case "text", "table":
+		// too deeply nested for a table
+		fmt.Println(printers.ToYAML(defaultCr))
+	default:
+		fmt.Println(c.Render(defaultCr))
 	}
 
 }
 
-func getDefaultCR(ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/defaultcr?version=%d", ver)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
-}
-
 func init() {
 	getCmd.AddCommand(getDefaultCRCmd)
 
@@ -0,0 +1,215 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	typed "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// kindAliases - the short and plural names --for accepts for a kind
+var kindAliases = map[string]string{
+	"po":                     "pod",
+	"pods":                   "pod",
+	"sts":                    "statefulset",
+	"statefulsets":           "statefulset",
+	"pvc":                    "persistentvolumeclaim",
+	"persistentvolumeclaims": "persistentvolumeclaim",
+	"svc":                    "service",
+	"services":               "service",
+	"deploy":                 "deployment",
+	"deployments":            "deployment",
+	"rs":                     "replicaset",
+	"replicasets":            "replicaset",
+	"jobs":                   "job",
+	"cj":                     "cronjob",
+	"cronjobs":               "cronjob",
+	"ing":                    "ingress",
+	"ingresses":              "ingress",
+}
+
+// eventFilter - the events --type and --for select, empty fields match
+// every event
+type eventFilter struct {
+	eventType string
+	kind      string
+	name      string
+}
+
+var getEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Get the Kubernetes events of a workspace namespace",
+	Long: `EXAMPLES
+	splicectl get events -d splicedb
+	splicectl get events -d splicedb --type Warning
+	splicectl get events -d splicedb --for pod/splicedb-hregion-0 -o wide
+	splicectl get events -d splicedb --type Warning --watch
+	splicectl get events -d splicedb -o json
+
+	The events are sorted by when they were last seen, oldest first.
+	--type selects Normal or Warning events, --for the events of one object
+	given as kind/name, kinds can be short like po, sts or pvc.  --watch
+	keeps polling and shows new and changed events.
+
+	Note: --database-name and -d are the preferred way to supply the database name.
+	However, --database and --workspace can also be used as well. In the event that
+	more than one of them is supplied database-name and d are preferred over all
+	and workspace is preferred over database. The most preferred option that is
+	supplied will be used and a message will be displayed letting you know which
+	option was chosen if more than one were supplied.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		eventType, _ := cmd.Flags().GetString("type")
+		forObject, _ := cmd.Flags().GetString("for")
+		filter, err := newEventFilter(eventType, forObject)
+		if err != nil {
+			c.FatalError(err, "Invalid event filter")
+		}
+
+		dbNamespace, err := getDBNamespace(cmd)
+		if err != nil {
+			c.FatalError(err, "Could not get events due to database name error")
+		}
+		client, err := common.KubeClient()
+		if err == nil && client == nil {
+			err = errors.New("no kubernetes config was found")
+		}
+		if err != nil {
+			c.FatalError(err, "Could not get kube config to list events")
+		}
+		ei := client.CoreV1().Events(dbNamespace)
+
+		if watch, interval := common.WatchFlags(cmd); watch {
+			c.Watch(context.Background(), cmd.CommandPath()+" ("+dbNamespace+")", interval, func(ctx context.Context) (config.Outputable, error) {
+				return listEvents(ctx, ei, filter)
+			})
+			return
+		}
+
+		events, err := listEvents(context.TODO(), ei, filter)
+		if err != nil {
+			c.FatalError(err, "Could not list events")
+		}
+		c.OutputData(events)
+	},
+}
+
+// newEventFilter - the filter of --type and --for, --for is kind/name
+func newEventFilter(eventType, forObject string) (eventFilter, error) {
+	filter := eventFilter{}
+	switch strings.ToLower(eventType) {
+	case "":
+	case "normal":
+		filter.eventType = core.EventTypeNormal
+	case "warning":
+		filter.eventType = core.EventTypeWarning
+	default:
+		return filter, fmt.Errorf("--type must be %s or %s, got '%s'", core.EventTypeNormal, core.EventTypeWarning, eventType)
+	}
+	if len(forObject) > 0 {
+		parts := strings.SplitN(forObject, "/", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return filter, fmt.Errorf("--for must be kind/name, ie: pod/splicedb-hregion-0, got '%s'", forObject)
+		}
+		filter.kind = strings.ToLower(parts[0])
+		if kind, ok := kindAliases[filter.kind]; ok {
+			filter.kind = kind
+		}
+		filter.name = parts[1]
+	}
+	return filter, nil
+}
+
+// matches - true when event is selected by the filter
+func (ef eventFilter) matches(event *core.Event) bool {
+	if len(ef.eventType) > 0 && event.Type != ef.eventType {
+		return false
+	}
+	if len(ef.kind) > 0 && (strings.ToLower(event.InvolvedObject.Kind) != ef.kind || event.InvolvedObject.Name != ef.name) {
+		return false
+	}
+	return true
+}
+
+// listEvents - the events of ei the filter selects, oldest first
+func listEvents(ctx context.Context, ei typed.EventInterface, filter eventFilter) (*objects.EventList, error) {
+	list, err := ei.List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	events := &objects.EventList{Events: make([]objects.Event, 0, len(list.Items))}
+	for i := range list.Items {
+		if filter.matches(&list.Items[i]) {
+			events.Events = append(events.Events, toEvent(&list.Items[i]))
+		}
+	}
+	sort.SliceStable(events.Events, func(i, j int) bool {
+		a, b := events.Events[i], events.Events[j]
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.Before(b.LastSeen)
+		}
+		return a.Name < b.Name
+	})
+	return events, nil
+}
+
+// toEvent - the fields of a Kubernetes event that are shown, events from
+// the newer API only have an EventTime
+func toEvent(event *core.Event) objects.Event {
+	lastSeen := event.LastTimestamp.Time
+	if lastSeen.IsZero() {
+		lastSeen = event.EventTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = event.CreationTimestamp.Time
+	}
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = lastSeen
+	}
+	count := event.Count
+	if count == 0 {
+		count = 1
+	}
+	source := event.Source.Component
+	if len(event.Source.Host) > 0 {
+		source += ", " + event.Source.Host
+	}
+	if len(source) == 0 {
+		source = event.ReportingController
+	}
+	return objects.Event{
+		LastSeen:  lastSeen.Truncate(time.Second),
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Object:    strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name,
+		Count:     count,
+		Message:   strings.TrimSpace(event.Message),
+		FirstSeen: firstSeen.Truncate(time.Second),
+		Source:    source,
+		Name:      event.Name,
+	}
+}
+
+func init() {
+	getCmd.AddCommand(getEventsCmd)
+
+	// add database name and aliases
+	getEventsCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
+	getEventsCmd.Flags().String("database", "", "Alias for database-name, prefer the use of -d and --database-name.")
+	getEventsCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
+
+	getEventsCmd.Flags().String("type", "", "Only events of a type, Normal or Warning")
+	getEventsCmd.Flags().String("for", "", "Only events about an object, as kind/name, ie: pod/splicedb-hregion-0")
+	common.AddWatchFlags(getEventsCmd)
+}
@@ -0,0 +1,113 @@
+package get
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEvent(name, eventType, kind, object, reason string, last time.Time) *core.Event {
+	return &core.Event{
+		ObjectMeta:     v1.ObjectMeta{Name: name, Namespace: "db"},
+		Type:           eventType,
+		Reason:         reason,
+		InvolvedObject: core.ObjectReference{Kind: kind, Name: object},
+		LastTimestamp:  v1.Time{Time: last},
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	kube := fake.NewSimpleClientset(
+		testEvent("b", core.EventTypeWarning, "Pod", "hregion-0", "OOMKilling", now),
+		testEvent("a", core.EventTypeNormal, "Pod", "hregion-0", "Pulled", now.Add(-time.Hour)),
+		testEvent("c", core.EventTypeWarning, "StatefulSet", "hregion", "FailedCreate", now.Add(-time.Minute)),
+	)
+	ei := kube.CoreV1().Events("db")
+
+	for _, tc := range []struct {
+		eventType, forObject string
+		want                 []string
+	}{
+		{"", "", []string{"Pulled", "FailedCreate", "OOMKilling"}},
+		{"warning", "", []string{"FailedCreate", "OOMKilling"}},
+		{"", "po/hregion-0", []string{"Pulled", "OOMKilling"}},
+		{"Warning", "sts/hregion", []string{"FailedCreate"}},
+	} {
+		filter, err := newEventFilter(tc.eventType, tc.forObject)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := listEvents(context.TODO(), ei, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, e := range events.Events {
+			got = append(got, e.Reason)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("--type %q --for %q: got %v, want %v", tc.eventType, tc.forObject, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("--type %q --for %q: got %v, want %v", tc.eventType, tc.forObject, got, tc.want)
+				break
+			}
+		}
+	}
+
+	for _, bad := range [][2]string{{"Error", ""}, {"", "hregion-0"}, {"", "pod/"}} {
+		if _, err := newEventFilter(bad[0], bad[1]); err == nil {
+			t.Errorf("--type %q --for %q should be rejected", bad[0], bad[1])
+		}
+	}
+}
+
+func TestWatchEvents(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	kube := fake.NewSimpleClientset(
+		testEvent("a", core.EventTypeNormal, "Pod", "hregion-0", "Pulled", now.Add(-time.Hour)),
+		testEvent("b", core.EventTypeWarning, "Pod", "hregion-0", "BackOff", now.Add(-time.Minute)),
+	)
+	ei := kube.CoreV1().Events("db")
+	poll := func() *printers.Table {
+		events, err := listEvents(context.TODO(), ei, eventFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		table, err := common.WatchTable(events, printers.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return table
+	}
+	prev := poll()
+
+	// a is seen again, it is now the newest event
+	event, err := ei.Get(context.TODO(), "a", v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Count = 2
+	event.LastTimestamp = v1.Time{Time: now}
+	if _, err := ei.Update(context.TODO(), event, v1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := common.DiffTables(prev, poll(), now)
+	if len(changes) != 1 || changes[0].Type != objects.WatchModified || changes[0].Key != "a" {
+		t.Fatalf("bumping the count of a = %+v, want a modified", changes)
+	}
+	if count := changes[0].Changes["COUNT"]; count.Old != "1" || count.New != "2" {
+		t.Errorf("COUNT change = %+v, want 1→2", count)
+	}
+}
@@ -1,14 +1,15 @@
 package get
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,7 @@ var getImageTag = &cobra.Command{
 	Short: "Get the image tag for a component of a database.",
 	Long: `EXAMPLES
 	splicectl get image-tag --component-name "hbase" --database-name "cjdb"
+	splicectl get image-tag --component-name "hbase" --database-name "cjdb" -w
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		var dberr error
@@ -28,20 +30,27 @@ var getImageTag = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of Databases", dberr)
+				c.FatalError(dberr, "Could not get a list of Databases")
 			}
 		}
 
-		out, err := getImageTagData(componentName, databaseName)
+		if watch, interval := common.WatchFlags(cmd); watch {
+			c.Watch(context.Background(), cmd.CommandPath()+" -d "+databaseName, interval, func(ctx context.Context) (config.Outputable, error) {
+				return c.APIClient().GetImageTags(ctx, databaseName, componentName)
+			})
+			return
+		}
+
+		out, raw, err := c.APIClient().GetImageTagsRaw(context.TODO(), databaseName, componentName)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting image tag for component")
+			c.FatalError(err, "Error getting image tag for component")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.16 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayGetImageTagV1(out)
+				displayGetImageTagV1(raw)
 			}
 		}
 
@@ -49,48 +58,18 @@ var getImageTag = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayGetImageTagV2(out)
+				displayGetImageTagV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayGetImageTagV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayGetImageTagV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func displayGetImageTagV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-
-	var tags []objects.ImageTag
-
-	marshErr := json.Unmarshal([]byte(in), &tags)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	tagList := objects.ImageTagList{
-		ImageTags: tags,
-	}
-
-	c.OutputData(&tagList)
-
-}
-
-func getImageTagData(componenetName string, databaseName string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/splicedb/imagetag?component-name=%s&database-name=%s", componenetName, databaseName)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-	return string(resp.Body()[:]), nil
+func displayGetImageTagV2(tagList *objects.ImageTagList, raw json.RawMessage) {
+	c.OutputRaw(tagList, raw)
 }
 
 func init() {
@@ -99,6 +78,8 @@ func init() {
 	getImageTag.Flags().StringP("component-name", "c", "", "Specify the component")
 	getImageTag.Flags().StringP("database-name", "d", "", "Specify the database name")
 
+	common.AddWatchFlags(getImageTag)
+
 	getImageTag.MarkFlagRequired("component-name")
 	// getImageTag.MarkFlagRequired("database-name")
 
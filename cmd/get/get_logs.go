@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
 	"os"
+	"os/signal"
 	"path"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 	core "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,23 +23,25 @@ import (
 	"k8s.io/client-go/rest"
 )
 
-type (
-	requestBuffer chan core.Pod
-	ticket        struct{}
-)
-
 const (
 	// Common flag names
-	selectorFlag  = "selector"
-	directoryFlag = "directory"
-	allFlag       = "all"
-	quietFlag     = "quiet"
+	selectorFlag       = "selector"
+	directoryFlag      = "directory"
+	archiveFlag        = "archive"
+	overwriteFlag      = "overwrite"
+	maxConcurrencyFlag = "max-concurrency"
+	allFlag            = "all"
+	quietFlag          = "quiet"
+	followFlag         = "follow"
 )
 
-var (
-	// Single value that ticket can take on
-	ticketV ticket = struct{}{}
-)
+// LogSink - where the saved logs are written, a directory or an archive
+type LogSink interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+// dirSink - writes the logs as files below a directory
+type dirSink string
 
 var getLogsCmd = &cobra.Command{
 	Use:   "logs",
@@ -42,6 +50,32 @@ var getLogsCmd = &cobra.Command{
 	splicectl get logs --workspace splicedb --directory ./logs
 	or
 	splicectl get logs -d splicedb -f ./logs
+	splicectl get logs -d splicedb --archive splicedb-logs.tar.gz
+	splicectl get logs -d splicedb --follow
+	splicectl get logs -d splicedb --all --follow
+
+	--archive writes the logs into a gzipped tarball instead of a directory.
+	Each log is spooled to a hidden file next to the archive while it is
+	downloaded, so that directory needs room for --max-concurrency logs on
+	top of the archive.  A directory that is not empty or an archive that exists is only replaced
+	with --overwrite.  --max-concurrency pods are downloaded at once, a table
+	of the bytes saved and errors of each pod is shown at the end.
+
+	--follow streams the logs of every container of the matching pods to
+	stdout instead of writing files, each line starts with pod/container.
+	The last 10 lines of the running containers are shown first, pods that
+	start later are picked up and restarted containers are followed again.
+	Ctrl-C stops following.
+
+	--since, --since-time, --tail and --limit-bytes limit the part of each
+	container log that is fetched, --timestamps starts each line with its
+	timestamp.  With --follow they apply to the containers running when it
+	starts.  The log of the previous instance of a container that restarted
+	is saved next to its log as <container>.previous.log, --previous fetches
+	only the previous logs, of the containers that restarted.
+
+	splicectl get logs -d splicedb -f ./logs --since 2h --timestamps
+	splicectl get logs -d splicedb --follow --tail 100
 
 	By default the selector is set to 'app=hbase', but that can be overridden 
 	using the --selector/-s flag or the --all/-a flag which ignores the selector
@@ -56,44 +90,92 @@ var getLogsCmd = &cobra.Command{
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		var (
-			selector, _ = cmd.Flags().GetString(selectorFlag)
-			dirName, _  = cmd.Flags().GetString(directoryFlag)
-			all, _      = cmd.Flags().GetBool(allFlag)
-			quiet, _    = cmd.Flags().GetBool(quietFlag)
+			selector, _    = cmd.Flags().GetString(selectorFlag)
+			dirName, _     = cmd.Flags().GetString(directoryFlag)
+			archiveName, _ = cmd.Flags().GetString(archiveFlag)
+			overwrite, _   = cmd.Flags().GetBool(overwriteFlag)
+			concurrency, _ = cmd.Flags().GetInt(maxConcurrencyFlag)
+			all, _         = cmd.Flags().GetBool(allFlag)
+			quiet, _       = cmd.Flags().GetBool(quietFlag)
+			follow, _      = cmd.Flags().GetBool(followFlag)
 		)
 
 		setLogLevel(quiet)
+		switch {
+		case follow && (len(dirName) > 0 || len(archiveName) > 0):
+			logrus.Fatalf("--%s writes to stdout, it can not be combined with --%s or --%s", followFlag, directoryFlag, archiveFlag)
+		case len(dirName) > 0 && len(archiveName) > 0:
+			logrus.Fatalf("--%s and --%s can not be combined", directoryFlag, archiveFlag)
+		case !follow && len(dirName) == 0 && len(archiveName) == 0:
+			logrus.Fatalf("required flag \"%s\" or \"%s\" not set, or use --%s", directoryFlag, archiveFlag, followFlag)
+		case concurrency < 1:
+			logrus.Fatalf("--%s must be at least 1, got %d", maxConcurrencyFlag, concurrency)
+		}
+		window, err := logWindowFlags(cmd, time.Now())
+		if err != nil {
+			c.FatalError(err, "Invalid log window")
+		}
+		if follow && window.previous {
+			logrus.Fatalf("--%s can not be combined with --%s", previousFlag, followFlag)
+		}
 
 		dbNamespace, err := getDBNamespace(cmd)
 		if err != nil {
-			logrus.WithError(err).Fatal("could not stream logs due to database name error")
+			c.FatalError(err, "Could not stream logs due to database name error")
 		}
 
 		selector = allOrDefault(all, selector)
 
 		client, err := common.KubeClient()
 		if err != nil {
-			logrus.WithError(err).Fatal("could not get kube config to generate core urls")
+			c.FatalError(err, "Could not get kube config to stream logs")
 		}
 
 		pi := client.CoreV1().Pods(dbNamespace)
+		if follow {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			status := io.Writer(os.Stderr)
+			if quiet {
+				status = ioutil.Discard
+			}
+			if err := newLogFollower(pi, selector, window, os.Stdout, status).run(ctx); err != nil {
+				c.FatalError(err, "Could not follow the logs")
+			}
+			return
+		}
+
 		pods, err := pi.List(context.TODO(), v1.ListOptions{LabelSelector: selector})
 		if err != nil {
-			logrus.WithError(err).Fatal("could not list pods")
+			c.FatalError(err, "Could not list pods")
 		}
 
-		if err := makeDirectory(dirName); err != nil {
-			logrus.WithError(err).Fatal("could not stream logs due to directory issue")
+		var sink LogSink
+		var archive *common.Archive
+		if len(archiveName) > 0 {
+			if archive, err = common.CreateArchive(archiveName, overwrite); err != nil {
+				c.FatalError(err, "Could not stream logs due to archive issue")
+			}
+			sink = archive
+		} else {
+			if err := makeDirectory(dirName, overwrite); err != nil {
+				c.FatalError(err, "Could not stream logs due to directory issue")
+			}
+			sink = dirSink(dirName)
 		}
 
-		reqBuffer, wg := make(requestBuffer, 1), &sync.WaitGroup{}
-		go streamLogs(reqBuffer, wg, dirName, pi)
-
-		for _, pod := range pods.Items {
-			wg.Add(1)
-			reqBuffer <- pod
+		summary := streamLogs(pods.Items, sink, pi, window, concurrency)
+		if archive != nil {
+			if err := archive.Close(); err != nil {
+				c.FatalError(err, "Could not write archive: "+archiveName)
+			}
+		}
+		if !quiet {
+			c.OutputData(summary)
+		}
+		if failed := summary.Errors(); failed > 0 {
+			logrus.Fatalf("%d logs could not be saved", failed)
 		}
-		wg.Wait()
 	},
 }
 
@@ -112,19 +194,45 @@ func allOrDefault(all bool, def string) string {
 	return def
 }
 
-// makeDirectory - makes the directory to output logs to, or clears a pre-existing one
-func makeDirectory(dirName string) error {
-	if _, err := os.Stat(dirName); err == nil {
+// makeDirectory - makes the directory to output logs to, an empty one is
+// reused, anything else that exists is only cleared when overwrite is set
+func makeDirectory(dirName string, overwrite bool) error {
+	if info, err := os.Stat(dirName); err == nil {
+		if info.IsDir() {
+			entries, err := ioutil.ReadDir(dirName)
+			if err != nil {
+				return fmt.Errorf("%v; could not read directory: %s", err, dirName)
+			}
+			if len(entries) == 0 {
+				return nil
+			}
+		}
+		if !overwrite {
+			return fmt.Errorf("'%s' already exists and is not an empty directory, use --%s to replace it", dirName, overwriteFlag)
+		}
 		if err := os.RemoveAll(dirName); err != nil {
 			return fmt.Errorf("%v; directory or file with name '%s', existed and could not be deleted", err, dirName)
 		}
 	}
-	if err := os.Mkdir(dirName, 0755); err != nil {
+	if err := os.MkdirAll(dirName, 0755); err != nil {
 		return fmt.Errorf("%v; could not make directory: %s", err, dirName)
 	}
 	return nil
 }
 
+// Create - creates the file name below the directory
+func (ds dirSink) Create(name string) (io.WriteCloser, error) {
+	filName := filepath.Join(string(ds), name)
+	if err := os.MkdirAll(filepath.Dir(filName), 0755); err != nil {
+		return nil, fmt.Errorf("%v; could not make directory: %s", err, filepath.Dir(filName))
+	}
+	fil, err := os.OpenFile(filName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("%v; could not open file: %s", err, filName)
+	}
+	return fil, nil
+}
+
 // getDBNamespace - get the namespace of the desired db based on command line flags
 func getDBNamespace(cmd *cobra.Command) (string, error) {
 	var dberr error
@@ -132,14 +240,14 @@ func getDBNamespace(cmd *cobra.Command) (string, error) {
 	if len(dbName) == 0 {
 		dbName, dberr = c.PromptForDatabaseName()
 		if dberr != nil {
-			return "", fmt.Errorf("%v; could not get a list of databases", dberr)
+			return "", fmt.Errorf("%w; could not get a list of databases", dberr)
 		}
 	}
 
 	dbNamespace := ""
 	list, err := c.GetDatabaseListStruct()
 	if err != nil {
-		return "", fmt.Errorf("%v; could not get list of databases", err)
+		return "", fmt.Errorf("%w; could not get list of databases", err)
 	}
 	for _, db := range list.Clusters {
 		if db.DcosAppId == dbName {
@@ -153,77 +261,118 @@ func getDBNamespace(cmd *cobra.Command) (string, error) {
 	return dbNamespace, nil
 }
 
-// podLogOptions - create PodLogOptions with just Container field being set
-func podLogOptions(containerName string) *core.PodLogOptions {
-	return &core.PodLogOptions{
-		Container: containerName,
+// SaveLogs - saves the log of every container of pods into sink the way
+// get logs does, at most concurrency pods at once.  Only the lines of the
+// last since and the last tail lines of each log are saved, a zero since
+// or a negative tail keep the whole log.
+func SaveLogs(pods []core.Pod, sink LogSink, pi typed.PodInterface, since time.Duration, tail int64, concurrency int) *objects.LogSummaryList {
+	window := logWindow{}
+	if seconds := int64(math.Ceil(since.Seconds())); seconds > 0 {
+		window.sinceSeconds = &seconds
+	}
+	if tail >= 0 {
+		window.tail = &tail
 	}
+	return streamLogs(pods, sink, pi, window, concurrency)
 }
 
-// streamLogs - stream all logs that are requested from the names channel
-func streamLogs(pods requestBuffer, waiter *sync.WaitGroup, dirName string, pi typed.PodInterface) {
-	buf := make(chan ticket, cap(pods))
-	for pod := range pods {
-		buf <- ticketV
-		go func(pod core.Pod) {
-			defer waiter.Done()
-			streamLog(pod, dirName, pi)
-			<-buf
-		}(pod)
+// streamLogs - stream the logs of pods into sink, at most concurrency
+// pods at once, what was saved of each pod is returned in the order of pods
+func streamLogs(pods []core.Pod, sink LogSink, pi typed.PodInterface, window logWindow, concurrency int) *objects.LogSummaryList {
+	summary := &objects.LogSummaryList{Pods: make([]objects.LogSummary, len(pods))}
+	slots := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for idx := range pods {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(idx int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			summary.Pods[idx] = streamLog(&pods[idx], sink, pi, window)
+		}(idx)
 	}
+	wg.Wait()
+	return summary
 }
 
-// streamLog - stream a single log from kubernetes into a file
-func streamLog(pod core.Pod, dirName string, pi typed.PodInterface) {
-	amount := int64(0)
-	filName := dirName
+// streamLog - stream the logs of every container of a pod, a pod with one
+// container is saved as <pod>.log, the others as <pod>/<container>.log
+func streamLog(pod *core.Pod, sink LogSink, pi typed.PodInterface, window logWindow) objects.LogSummary {
+	result := objects.LogSummary{Pod: pod.Name}
 	if len(pod.Spec.Containers) == 1 {
-		filName = path.Join(filName, pod.Name+".log")
-		amount = streamContainerLog(filName, pi.GetLogs(pod.Name, podLogOptions("")))
+		streamContainerLogs(&result, pod.Name, pod, pod.Spec.Containers[0].Name, sink, pi, window)
 	} else {
-		filName = path.Join(filName, pod.Name)
-		if err := makeDirectory(filName); err != nil {
-			logrus.WithError(err).Errorf("could not make directory: %s", filName)
-			return
-		}
 		for _, containers := range [][]core.Container{
 			pod.Spec.Containers,
 			pod.Spec.InitContainers,
 		} {
 			for _, container := range containers {
-				innerFilName := path.Join(filName, container.Name+".log")
-				amount += streamContainerLog(innerFilName, pi.GetLogs(pod.Name, podLogOptions(container.Name)))
+				streamContainerLogs(&result, path.Join(pod.Name, container.Name), pod, container.Name, sink, pi, window)
 			}
 		}
 	}
-	logrus.Infof("wrote %10d bytes to %s", amount, filName)
+	logrus.Infof("wrote %10d bytes of %s", result.Bytes, pod.Name)
+	return result
 }
 
-// streamContainerLog - stream a log from a single container of a pod into kubernetes
-func streamContainerLog(filName string, req *rest.Request) int64 {
-	// Open file to write log data
-	fil, err := os.OpenFile(filName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0777)
-	if err != nil {
-		logrus.WithError(err).Errorf("could not open file: %s", filName)
-		return 0
+// streamContainerLogs - stream the log of a container into base.log, the
+// previous log of a container that restarted goes into base.previous.log
+func streamContainerLogs(result *objects.LogSummary, base string, pod *core.Pod, container string, sink LogSink, pi typed.PodInterface, window logWindow) {
+	save := func(name string, previous bool) {
+		amount, err := streamContainerLog(sink, name, pi.GetLogs(pod.Name, window.podLogOptions(container, previous)))
+		result.Logs++
+		result.Bytes += amount
+		if err != nil {
+			logrus.WithError(err).Errorf("could not save %s", name)
+			result.Errors++
+			if len(result.Error) == 0 {
+				result.Error = err.Error()
+			}
+		}
+	}
+	if window.previous {
+		// a container that never restarted has no previous log to ask for
+		if restartCount(pod, container) > 0 {
+			save(base+".previous.log", true)
+		} else {
+			logrus.Infof("%s/%s has not restarted, it has no previous log", pod.Name, container)
+		}
+		return
+	}
+	save(base+".log", false)
+	if restarts := restartCount(pod, container); restarts > 0 {
+		logrus.Infof("%s/%s restarted %d times, saving the previous log too", pod.Name, container, restarts)
+		save(base+".previous.log", true)
 	}
-	defer fil.Close()
+}
 
+// streamContainerLog - stream a log from a single container of a pod into
+// the entry name of sink, nothing is written when the log can't be read
+func streamContainerLog(sink LogSink, name string, req *rest.Request) (int64, error) {
 	// Start stream of log data
 	stream, err := req.Stream(context.Background())
 	if err != nil {
-		logrus.WithError(err).Errorf("could not get log for %s", filName)
-		return 0
+		return 0, fmt.Errorf("could not get log: %w", err)
 	}
 	defer stream.Close()
 
-	// Stream data from http request to file
-	amount, err := io.Copy(fil, stream)
+	// Open entry to write log data
+	out, err := sink.Create(name)
+	if err != nil {
+		return 0, err
+	}
+
+	// Stream data from http request to the entry
+	amount, err := io.Copy(out, stream)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
 	if err != nil {
-		logrus.WithError(err).Errorf("could not write log to file: %s", filName)
-		return 0
+		return amount, fmt.Errorf("could not write log: %w", err)
 	}
-	return amount
+	return amount, nil
 }
 
 func init() {
@@ -232,14 +381,19 @@ func init() {
 
 	getLogsCmd.Flags().StringP(selectorFlag, "s", "app=hbase", "kubernetes selector expresssion to filter pods on")
 	getLogsCmd.Flags().StringP(directoryFlag, "f", "", "name of folder to output logs to")
+	getLogsCmd.Flags().String(archiveFlag, "", "name of a .tar.gz file to output logs to instead of --directory")
+	getLogsCmd.Flags().Bool(overwriteFlag, false, "replace a --directory that is not empty or an --archive that exists")
+	getLogsCmd.Flags().Int(maxConcurrencyFlag, 5, "how many pods are downloaded at once")
+	getLogsCmd.Flags().Bool(followFlag, false, "stream the logs to stdout as they are written instead of to --directory")
+	addLogWindowFlags(getLogsCmd)
 
 	// add database name and aliases
 	getLogsCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
 	getLogsCmd.Flags().String("database", "", "Alias for database-name, prefer the use of -d and --database-name.")
 	getLogsCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
-	getLogsCmd.MarkFlagRequired(directoryFlag)
 	getLogsCmd.MarkFlagDirname(directoryFlag)
+	getLogsCmd.MarkFlagFilename(archiveFlag, "tar.gz", "tgz")
 
 	getCmd.AddCommand(getLogsCmd)
 }
@@ -0,0 +1,329 @@
+package get
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	typed "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// followTail - the lines of the containers running when --follow starts
+// that are shown before new lines when no window was given, containers
+// started later are shown from their first line
+const followTail = int64(10)
+
+// followMaxFailures - the attempts in a row to stream a container that may
+// fail before the container is no longer followed
+const followMaxFailures = 5
+
+// followMaxDelay - the longest wait before streaming a container again
+const followMaxDelay = 30 * time.Second
+
+// prefixColors - the colors a pod or container name is shown in, picked by
+// a hash of the name so it keeps its color between runs
+var prefixColors = []*color.Color{
+	color.New(color.FgCyan),
+	color.New(color.FgGreen),
+	color.New(color.FgMagenta),
+	color.New(color.FgYellow),
+	color.New(color.FgBlue),
+	color.New(color.FgHiCyan),
+	color.New(color.FgHiGreen),
+	color.New(color.FgHiMagenta),
+}
+
+func prefixColor(name string) *color.Color {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return prefixColors[h.Sum32()%uint32(len(prefixColors))]
+}
+
+// logPrefix - 'pod/container ' in the colors of the pod and the container
+func logPrefix(pod, container string) string {
+	return prefixColor(pod).Sprint(pod) + "/" + prefixColor(container).Sprint(container) + " "
+}
+
+// lineWriter - writes whole lines from many streams, a line is never cut
+// by a line of another stream
+type lineWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (lw *lineWriter) line(prefix, text string) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	fmt.Fprintf(lw.out, "%s%s\n", prefix, text)
+}
+
+// followedContainer - what was last streamed from a container, so a stream
+// that is cut short carries on where it stopped rather than from the start
+type followedContainer struct {
+	containerID string
+	active      bool
+	last        time.Time
+	failures    int
+	stopped     bool
+}
+
+// logFollower - streams the logs of every container of the pods matching a
+// selector to one writer.  Pods that appear later are picked up from a
+// watch of the pod list, a restarted container is followed again.
+type logFollower struct {
+	pi       typed.PodInterface
+	selector string
+	window   logWindow
+	out      *lineWriter
+	status   io.Writer
+
+	mu         sync.Mutex
+	containers map[string]*followedContainer
+	wg         sync.WaitGroup
+}
+
+func newLogFollower(pi typed.PodInterface, selector string, window logWindow, out, status io.Writer) *logFollower {
+	return &logFollower{
+		pi:         pi,
+		selector:   selector,
+		window:     window,
+		out:        &lineWriter{out: out},
+		status:     status,
+		containers: map[string]*followedContainer{},
+	}
+}
+
+// run - follows the logs until ctx is done, the watch of the pods is
+// restarted whenever the API server ends it
+func (lf *logFollower) run(ctx context.Context) error {
+	defer lf.wg.Wait()
+	initial := true
+	for {
+		pods, err := lf.pi.List(ctx, v1.ListOptions{LabelSelector: lf.selector})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not list the pods: %w", err)
+		}
+		if initial && len(pods.Items) == 0 {
+			fmt.Fprintf(lf.status, "no pods match '%s' yet, waiting for them\n", lf.selector)
+		}
+		for i := range pods.Items {
+			lf.follow(ctx, &pods.Items[i], initial)
+		}
+		initial = false
+
+		w, err := lf.pi.Watch(ctx, v1.ListOptions{LabelSelector: lf.selector, ResourceVersion: pods.ResourceVersion})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("could not watch the pods: %w", err)
+		}
+		lf.watch(ctx, w)
+		w.Stop()
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// watch - starts following the containers of the pods as they change,
+// until the watch ends
+func (lf *logFollower) watch(ctx context.Context, w watch.Interface) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			if pod, isPod := event.Object.(*core.Pod); isPod && (event.Type == watch.Added || event.Type == watch.Modified) {
+				lf.follow(ctx, pod, false)
+			}
+		}
+	}
+}
+
+// follow - starts a stream for every running container of pod that isn't
+// streamed yet
+func (lf *logFollower) follow(ctx context.Context, pod *core.Pod, initial bool) {
+	for _, cs := range containerStatuses(pod) {
+		if cs.State.Running == nil {
+			continue
+		}
+		key := pod.Name + "/" + cs.Name
+
+		lf.mu.Lock()
+		fc, known := lf.containers[key]
+		if known && (fc.active || fc.stopped && fc.containerID == cs.ContainerID) {
+			lf.mu.Unlock()
+			continue
+		}
+		opts := &core.PodLogOptions{Container: cs.Name, Follow: true, Timestamps: lf.window.timestamps}
+		switch {
+		case known && fc.containerID == cs.ContainerID:
+			// the stream was cut short
+			opts.SinceTime = &v1.Time{Time: fc.last}
+		case known:
+			lf.out.line(logPrefix(pod.Name, cs.Name), color.New(color.Faint).Sprintf("--- restarted, restart count %d ---", cs.RestartCount))
+		case initial:
+			opts = lf.window.podLogOptions(cs.Name, false)
+			opts.Follow = true
+			if opts.TailLines == nil && opts.SinceSeconds == nil && opts.SinceTime == nil {
+				tail := followTail
+				opts.TailLines = &tail
+			}
+		}
+		if !known {
+			fc = &followedContainer{}
+			lf.containers[key] = fc
+		}
+		if fc.containerID != cs.ContainerID {
+			// a restarted container gets a fresh start
+			fc.failures, fc.stopped = 0, false
+		}
+		fc.containerID = cs.ContainerID
+		fc.active = true
+		fc.last = time.Now()
+		lf.mu.Unlock()
+
+		lf.wg.Add(1)
+		go lf.stream(ctx, pod.Name, cs.Name, opts)
+	}
+}
+
+// stream - copies the log of one container line by line until it ends, the
+// pod is looked at again in case the container restarted meanwhile.  A stream
+// that can't be opened is tried again later, waiting longer every time, and
+// the container is no longer followed after followMaxFailures attempts or an
+// error of the API server that trying again won't fix.
+func (lf *logFollower) stream(ctx context.Context, pod, container string, opts *core.PodLogOptions) {
+	defer lf.wg.Done()
+	key := pod + "/" + container
+	prefix := logPrefix(pod, container)
+	fmt.Fprintf(lf.status, "+ %s\n", key)
+
+	rc, err := lf.pi.GetLogs(pod, opts).Stream(ctx)
+	if err == nil {
+		reader := bufio.NewReader(rc)
+		for {
+			text, err := reader.ReadString('\n')
+			if len(text) > 0 {
+				lf.out.line(prefix, strings.TrimRight(text, "\r\n"))
+				lf.mu.Lock()
+				lf.containers[key].last = time.Now()
+				lf.mu.Unlock()
+			}
+			if err != nil {
+				break
+			}
+		}
+		rc.Close()
+	}
+
+	fmt.Fprintf(lf.status, "- %s\n", key)
+	lf.mu.Lock()
+	fc := lf.containers[key]
+	fc.active = false
+	if err == nil {
+		fc.failures = 0
+	} else if ctx.Err() == nil {
+		fc.failures++
+		fc.stopped = !retryable(err) || fc.failures >= followMaxFailures
+	}
+	failures, stopped := fc.failures, fc.stopped
+	lf.mu.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+
+	switch {
+	case stopped && !retryable(err):
+		logrus.WithError(err).Errorf("stopped following %s, the API server refused its log", key)
+		return
+	case stopped:
+		logrus.WithError(err).Errorf("stopped following %s after %d failed attempts in a row", key, failures)
+		return
+	case err != nil:
+		delay := followRetryDelay(failures)
+		logrus.WithError(err).Warnf("could not follow %s, trying again in %s", key, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+
+	// the container may have restarted before this stream ended, in which
+	// case its event was ignored
+	if current, err := lf.pi.Get(ctx, pod, v1.GetOptions{}); err == nil {
+		// give a dying container a moment to be reported as terminated, a
+		// failed stream has waited already
+		if failures == 0 && running(current, container) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			if current, err = lf.pi.Get(ctx, pod, v1.GetOptions{}); err != nil {
+				return
+			}
+		}
+		lf.follow(ctx, current, false)
+	}
+}
+
+// followRetryDelay - the wait before streaming a container again after
+// failures attempts in a row failed, doubling from a second
+func followRetryDelay(failures int) time.Duration {
+	delay := time.Second
+	for i := 1; i < failures && delay < followMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > followMaxDelay {
+		delay = followMaxDelay
+	}
+	return delay
+}
+
+// retryable - false for an error of the API server that streaming again
+// won't fix, like a pod that is gone or a request that isn't allowed
+func retryable(err error) bool {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		code := status.Status().Code
+		return code == http.StatusTooManyRequests || code < 400 || code >= 500
+	}
+	return true
+}
+
+// containerStatuses - the statuses of the init containers and containers
+func containerStatuses(pod *core.Pod) []core.ContainerStatus {
+	return append(append([]core.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+}
+
+// running - true when container of pod is running
+func running(pod *core.Pod, container string) bool {
+	for _, cs := range containerStatuses(pod) {
+		if cs.Name == container {
+			return cs.State.Running != nil
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package get
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// syncBuffer - a buffer the test reads while the follower writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buf.String()
+}
+
+func runningPod(name string) *core.Pod {
+	return &core.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "db", Labels: map[string]string{"app": "hbase"}},
+		Status: core.PodStatus{ContainerStatuses: []core.ContainerStatus{
+			{Name: "hbase", ContainerID: "1", State: core.ContainerState{Running: &core.ContainerStateRunning{}}},
+		}},
+	}
+}
+
+func TestLogFollower(t *testing.T) {
+	kube := fake.NewSimpleClientset(runningPod("region-0"))
+	pi := kube.CoreV1().Pods("db")
+	out := &syncBuffer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- newLogFollower(pi, "app=hbase", logWindow{}, out, ioutil.Discard).run(ctx)
+	}()
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("%q was not followed, got:\n%s", want, out.String())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor("region-0")
+	waitFor("/hbase") // the prefix may be colored
+	waitFor("fake logs")
+
+	// a pod that starts later is picked up by the watch
+	if _, err := pi.Create(ctx, runningPod("region-1"), v1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("region-1")
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not stop when cancelled")
+	}
+}
+
+func TestFollowRetry(t *testing.T) {
+	for failures, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: followMaxDelay} {
+		if got := followRetryDelay(failures); got != want {
+			t.Errorf("followRetryDelay(%d) = %s, want %s", failures, got, want)
+		}
+	}
+
+	gr := schema.GroupResource{Resource: "pods"}
+	for err, want := range map[error]bool{
+		errors.New("connection reset"):                 true,
+		apierrors.NewInternalError(errors.New("boom")): true,
+		apierrors.NewTooManyRequests("slow down", 1):   true,
+		apierrors.NewNotFound(gr, "region-0"):          false,
+		apierrors.NewForbidden(gr, "region-0", nil):    false,
+		apierrors.NewBadRequest("no such container"):   false,
+	} {
+		if got := retryable(fmt.Errorf("stream: %w", err)); got != want {
+			t.Errorf("retryable(%v) = %t, want %t", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package get
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/common"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	sinceFlag      = "since"
+	sinceTimeFlag  = "since-time"
+	tailFlag       = "tail"
+	previousFlag   = "previous"
+	timestampsFlag = "timestamps"
+	limitBytesFlag = "limit-bytes"
+)
+
+// logWindow - the part of each container log that is fetched, nil fields
+// leave the whole log
+type logWindow struct {
+	sinceSeconds *int64
+	sinceTime    *v1.Time
+	tail         *int64
+	limitBytes   *int64
+	previous     bool
+	timestamps   bool
+}
+
+// addLogWindowFlags - add the flags read by logWindowFlags to cmd
+func addLogWindowFlags(cmd *cobra.Command) {
+	cmd.Flags().String(sinceFlag, "", "only logs newer than a duration, ie: 30m, 2h, 7d")
+	cmd.Flags().String(sinceTimeFlag, "", "only logs after a time (RFC3339), ie: 2021-06-01T15:04:05Z")
+	cmd.Flags().Int64(tailFlag, -1, "only the last lines of each container log, -1 for all")
+	cmd.Flags().Bool(previousFlag, false, "only the logs of the previous instance of each container that restarted")
+	cmd.Flags().Bool(timestampsFlag, false, "start each line with its timestamp")
+	cmd.Flags().Int64(limitBytesFlag, 0, "at most this many bytes of each container log, 0 for all")
+}
+
+// logWindowFlags - the values of the flags added by addLogWindowFlags
+func logWindowFlags(cmd *cobra.Command, now time.Time) (logWindow, error) {
+	lw := logWindow{}
+	since, _ := cmd.Flags().GetString(sinceFlag)
+	sinceTime, _ := cmd.Flags().GetString(sinceTimeFlag)
+	tail, _ := cmd.Flags().GetInt64(tailFlag)
+	limitBytes, _ := cmd.Flags().GetInt64(limitBytesFlag)
+	lw.previous, _ = cmd.Flags().GetBool(previousFlag)
+	lw.timestamps, _ = cmd.Flags().GetBool(timestampsFlag)
+
+	if len(since) > 0 && len(sinceTime) > 0 {
+		return lw, fmt.Errorf("--%s and --%s can not be combined", sinceFlag, sinceTimeFlag)
+	}
+	if len(since) > 0 {
+		start, err := common.ParseSince(since, now)
+		if err != nil {
+			return lw, fmt.Errorf("invalid --%s: %w", sinceFlag, err)
+		}
+		seconds := int64(math.Ceil(now.Sub(start).Seconds()))
+		if seconds <= 0 {
+			return lw, fmt.Errorf("--%s must be in the past, got '%s'", sinceFlag, since)
+		}
+		lw.sinceSeconds = &seconds
+	}
+	if len(sinceTime) > 0 {
+		t, err := time.Parse(time.RFC3339, sinceTime)
+		if err != nil {
+			return lw, fmt.Errorf("invalid --%s, expected RFC3339 like 2021-06-01T15:04:05Z: %w", sinceTimeFlag, err)
+		}
+		lw.sinceTime = &v1.Time{Time: t}
+	}
+	if tail >= 0 {
+		lw.tail = &tail
+	}
+	if limitBytes < 0 {
+		return lw, errors.New("--limit-bytes can not be negative")
+	}
+	if limitBytes > 0 {
+		lw.limitBytes = &limitBytes
+	}
+	return lw, nil
+}
+
+// podLogOptions - the PodLogOptions of container within the window, the
+// log of the previous instance of the container when previous is set
+func (lw logWindow) podLogOptions(container string, previous bool) *core.PodLogOptions {
+	return &core.PodLogOptions{
+		Container:    container,
+		Previous:     previous,
+		SinceSeconds: lw.sinceSeconds,
+		SinceTime:    lw.sinceTime,
+		TailLines:    lw.tail,
+		LimitBytes:   lw.limitBytes,
+		Timestamps:   lw.timestamps,
+	}
+}
+
+// restartCount - how often container of pod restarted
+func restartCount(pod *core.Pod, container string) int32 {
+	for _, cs := range containerStatuses(pod) {
+		if cs.Name == container {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package get
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func windowFor(t *testing.T, args ...string) (logWindow, error) {
+	t.Helper()
+	cmd := &cobra.Command{Use: "logs"}
+	addLogWindowFlags(cmd)
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return logWindowFlags(cmd, time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestLogWindowFlags(t *testing.T) {
+	lw, err := windowFor(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := lw.podLogOptions("hbase", false)
+	if opts.SinceSeconds != nil || opts.SinceTime != nil || opts.TailLines != nil || opts.LimitBytes != nil {
+		t.Errorf("no flags should fetch the whole log, got %+v", opts)
+	}
+
+	lw, err = windowFor(t, "--since", "2h", "--tail", "0", "--limit-bytes", "1024", "--timestamps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = lw.podLogOptions("hbase", true)
+	if *opts.SinceSeconds != 7200 || *opts.TailLines != 0 || *opts.LimitBytes != 1024 || !opts.Timestamps || !opts.Previous || opts.Container != "hbase" {
+		t.Errorf("unexpected options %+v", opts)
+	}
+
+	lw, err = windowFor(t, "--since-time", "2021-06-01T10:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lw.sinceTime == nil || lw.sinceTime.Hour() != 10 {
+		t.Errorf("unexpected since time %v", lw.sinceTime)
+	}
+
+	for _, args := range [][]string{
+		{"--since", "1h", "--since-time", "2021-06-01T10:00:00Z"},
+		{"--since-time", "yesterday"},
+		{"--limit-bytes", "-1"},
+	} {
+		if _, err := windowFor(t, args...); err == nil {
+			t.Errorf("%v should be rejected", args)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package get
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestMakeDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	if err := makeDirectory(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	// an empty directory is reused
+	if err := makeDirectory(dir, false); err != nil {
+		t.Fatal(err)
+	}
+	kept := filepath.Join(dir, "kept.log")
+	if err := ioutil.WriteFile(kept, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := makeDirectory(dir, false); err == nil {
+		t.Error("a directory that is not empty should not be cleared without overwrite")
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Errorf("the existing log was removed: %v", err)
+	}
+	if err := makeDirectory(dir, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(kept); !os.IsNotExist(err) {
+		t.Error("overwrite should clear the directory")
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	restarted := runningPod("region-1")
+	restarted.Status.ContainerStatuses[0].RestartCount = 2
+	multi := runningPod("master-0")
+	multi.Spec.Containers = []core.Container{{Name: "hbase"}, {Name: "sidecar"}}
+	pods := []core.Pod{*runningPod("region-0"), *restarted, *multi}
+	for i := range pods {
+		if len(pods[i].Spec.Containers) == 0 {
+			pods[i].Spec.Containers = []core.Container{{Name: "hbase"}}
+		}
+	}
+	kube := fake.NewSimpleClientset()
+	pi := kube.CoreV1().Pods("db")
+	for i := range pods {
+		if _, err := pi.Create(context.TODO(), &pods[i], v1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	summary := streamLogs(pods, dirSink(dir), pi, logWindow{}, 2)
+	if summary.Errors() != 0 || len(summary.Pods) != 3 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	for idx, want := range []int{1, 2, 2} {
+		if p := summary.Pods[idx]; p.Pod != pods[idx].Name || p.Logs != want || p.Bytes == 0 {
+			t.Errorf("unexpected summary of %s: %+v", pods[idx].Name, p)
+		}
+	}
+	for _, name := range []string{"region-0.log", "region-1.log", "region-1.previous.log", "master-0/hbase.log", "master-0/sidecar.log"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s was not saved: %v", name, err)
+		}
+	}
+
+	// --previous only asks for the log of containers that restarted
+	dir = t.TempDir()
+	summary = streamLogs(pods, dirSink(dir), pi, logWindow{previous: true}, 2)
+	if summary.Errors() != 0 {
+		t.Fatalf("unexpected summary with --previous %+v", summary)
+	}
+	for idx, want := range []int{0, 1, 0} {
+		if p := summary.Pods[idx]; p.Logs != want {
+			t.Errorf("%s saved %d previous logs, want %d", p.Pod, p.Logs, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "region-0.previous.log")); !os.IsNotExist(err) {
+		t.Errorf("region-0 never restarted but its previous log was saved: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package get
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 )
 
@@ -24,16 +26,17 @@ var getSystemSettingsCmd = &cobra.Command{
 		version, _ := cmd.Flags().GetInt("version")
 		decode, _ := cmd.Flags().GetBool("decode-values")
 
-		out, err := getSystemSettings(version)
+		out := &objects.SystemSettings{}
+		raw, err := c.APIClient().GetVaultRaw(context.TODO(), client.SystemSettings(), version, out)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting System Settings")
+			c.FatalError(err, "Error getting System Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayGetSystemSettingsV1(out)
+				displayGetSystemSettingsV1(raw)
 			}
 		}
 
@@ -41,61 +44,27 @@ var getSystemSettingsCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayGetSystemSettingsV2(out, decode)
+				displayGetSystemSettingsV2(out, raw, decode)
 			}
 		}
 	},
 }
 
-func displayGetSystemSettingsV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayGetSystemSettingsV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func systemSettingsToString(sessData objects.SystemSettings, dc bool) string {
-	switch strings.ToLower(c.OutputFormat) {
-	case "json":
-		return sessData.ToJSON()
-	case "gron":
-		return sessData.ToGRON()
-	case "yaml":
-		return sessData.ToYAML()
-	case "text", "table":
-		return sessData.ToText(c.NoHeaders, dc)
-	default:
-		return sessData.ToText(c.NoHeaders, dc)
-	}
-}
-
-func displayGetSystemSettingsV2(in string, dc bool) {
+func displayGetSystemSettingsV2(sessData *objects.SystemSettings, raw json.RawMessage, dc bool) {
 	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
+		fmt.Println(string(raw))
 		os.Exit(0)
 	}
-	var sessData objects.SystemSettings
-	marshErr := json.Unmarshal([]byte(in), &sessData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
 
 	if !c.FormatOverridden {
 		c.OutputFormat = "yaml"
 	}
-	fmt.Println(systemSettingsToString(sessData, dc))
-}
-
-func getSystemSettings(ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/systemsettings?version=%d", ver)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+	sessData.Decode = dc
+	fmt.Println(c.Render(sessData))
 }
 
 func init() {
@@ -19,7 +19,6 @@ type namedURL struct {
 
 const (
 	oauthProxySuffix = "-oauth2-proxy"
-	ssNameSpace      = "splice-system"
 	displayNameLabel = "displayName"
 	defaultNameLabel = "app"
 )
@@ -59,8 +58,11 @@ func getURLOutput(cmd *cobra.Command) string {
 	} else if prodOnly, _ := cmd.Flags().GetBool("prod"); prodOnly {
 		return generateBuildURLs()
 	} else {
-		dbNamespace, _ := getDBNamespace(cmd)
-		return generateURLsFromNamespaces(ssNameSpace, dbNamespace)
+		dbNamespace, err := getDBNamespace(cmd)
+		if err != nil {
+			c.FatalError(err, "Could not get urls due to database name error")
+		}
+		return generateURLsFromNamespaces(common.Target.Namespace, dbNamespace)
 	}
 }
 
@@ -1,16 +1,16 @@
 package get
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
-	"github.com/maahsome/gron"
 	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/printers"
 
 	"github.com/spf13/cobra"
 )
@@ -29,10 +29,11 @@ var getVaultKeyCmd = &cobra.Command{
 			keyPath = strings.TrimPrefix(keyPath, "secrets/")
 		}
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := getVaultKeyData(keyPath, version)
+		data, err := c.APIClient().GetVaultDocument(context.TODO(), client.VaultKey(keyPath), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error getting Vault Key Data")
 		}
+		out := string(data)
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
@@ -76,41 +77,15 @@ func displayGetVaultKeyV2(in string) {
 	switch strings.ToLower(c.OutputFormat) {
 	case "json":
 		fmt.Println(string(in[:]))
-	case "gron":
-		subReader := strings.NewReader(string(in[:]))
-		subValues := &bytes.Buffer{}
-		ges := gron.NewGron(subReader, subValues)
-		ges.SetMonochrome(false)
-		serr := ges.ToGron()
-		if serr != nil {
-			logrus.Error("Problem generating gron syntax", serr)
-		} else {
-			fmt.Println(string(subValues.Bytes()))
-		}
-	case "yaml", "text", "table":
-		rawVaultKey, crerr := yaml.Marshal(vaultKey)
-		if crerr != nil {
-			logrus.WithError(crerr).Error("Failed to convert to YAML")
-		}
-		fmt.Println(string(rawVaultKey[:]))
+	case "text", "table":
+		// too deeply nested for a table
+		fmt.Println(printers.ToYAML(vaultKey))
+	default:
+		fmt.Println(c.Render(vaultKey))
 	}
 
 }
 
-func getVaultKeyData(keypath string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/vaultkey?version=%d&keypath=%s", ver, keypath)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
-}
-
 func init() {
 	getCmd.AddCommand(getVaultKeyCmd)
 
@@ -1,14 +1,17 @@
 package list
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
 
 	"github.com/spf13/cobra"
 )
@@ -19,6 +22,15 @@ var listDatabaseCmd = &cobra.Command{
 	Short:   "Retrieve a list of splice databases in the cluster.",
 	Long: `EXAMPLES
 	splicectl list workspace
+	splicectl list workspace -o wide --sort-by created
+	splicectl list workspace --watch --watch-interval 10s
+	splicectl list workspace --filter account=acme --filter free-tier=true \
+	  --filter created-after=2021-02-01 --filter created-before=2021-03-01
+
+	--filter key=value may be repeated, every filter must match.  The keys
+	are account (name or id), status, free-tier, name, namespace, email,
+	cluster-id, created-before and created-after (a date or an age like 30d).
+	--sort-by takes any of the keys without before/after, ie: created.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("list_database")
@@ -34,17 +46,58 @@ var listDatabaseCmd = &cobra.Command{
 			logrus.WithError(err).Error("Error getting Database CR Info")
 		}
 
-		// databaseName, _ := cmd.Flags().GetString("database-name")
-		out, err := getDatabaseListWithFlags(active, paused)
+		filterSpecs, _ := cmd.Flags().GetStringArray("filter")
+		filters := make([]func(objects.CMClusterInfo) bool, 0, len(filterSpecs))
+		for _, spec := range filterSpecs {
+			filter, err := databaseFilter(spec, time.Now())
+			if err != nil {
+				logrus.WithError(err).Fatal("Invalid --filter")
+			}
+			filters = append(filters, filter)
+		}
+		sortBy, _ := cmd.Flags().GetString("sort-by")
+		if _, ok := objects.DatabaseField(sortBy); len(sortBy) > 0 && !ok {
+			logrus.Fatalf("Invalid --sort-by '%s', valid fields are [%s]", sortBy, strings.Join(objects.DatabaseFieldNames(), "|"))
+		}
+
+		narrowed := active || paused || len(filters) > 0 || len(sortBy) > 0
+		list := func(ctx context.Context) (*objects.DatabaseList, json.RawMessage, error) {
+			out, raw, err := c.ListDatabasesRaw(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !narrowed {
+				return out, raw, nil
+			}
+			out = filterDatabases(out.FilterByStatus(active, paused), filters)
+			if len(sortBy) > 0 {
+				if out, err = out.SortBy(sortBy); err != nil {
+					return nil, nil, err
+				}
+			}
+			// the body no longer matches the list, -o raw shows the list itself
+			raw, err = json.Marshal(out)
+			return out, raw, err
+		}
+
+		if watch, interval := common.WatchFlags(cmd); watch {
+			c.Watch(context.Background(), cmd.CommandPath(), interval, func(ctx context.Context) (config.Outputable, error) {
+				out, _, err := list(ctx)
+				return out, err
+			})
+			return
+		}
+
+		out, raw, err := list(context.TODO())
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Database CR Info")
+			c.FatalError(err, "Error getting workspace list")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.14 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayListDatabaseV1(out)
+				displayListDatabaseV1(raw)
 			}
 		}
 
@@ -52,57 +105,18 @@ var listDatabaseCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayListDatabaseV2(out)
+				displayListDatabaseV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayListDatabaseV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayListDatabaseV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var dbList objects.DatabaseList
-
-	marshErr := json.Unmarshal([]byte(in), &dbList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&dbList)
-
-}
-
-// getDatabaseList - simple wrapper around getDatabaseListWithFlags to prevent
-// cascading issues with change in API.
-func getDatabaseList() (string, error) {
-	return getDatabaseListWithFlags(false, false)
+func displayListDatabaseV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-// getDatabaseListWithFlags - gets list of databases and filters/orders them
-// based on flags.
-func getDatabaseListWithFlags(active, paused bool) (string, error) {
-	data, err := c.GetDatabaseList()
-	if err != nil {
-		return "", err
-	}
-
-	// filter and order DBList returned from api call
-	rawRespBody, dbList := data, &objects.DatabaseList{}
-	if err := json.Unmarshal(rawRespBody, dbList); err != nil {
-		return "", err
-	}
-	dbList = dbList.FilterByStatus(active, paused)
-	filteredDBList, err := json.Marshal(dbList)
-	// TODO: return either bytes or json, do not go back to strings
-	return string(filteredDBList), err
-
+func displayListDatabaseV2(dbList *objects.DatabaseList, raw json.RawMessage) {
+	c.OutputRaw(dbList, raw)
 }
 
 func init() {
@@ -110,4 +124,7 @@ func init() {
 
 	listDatabaseCmd.Flags().BoolP("active", "a", false, "Select if you want to get active databases.")
 	listDatabaseCmd.Flags().BoolP("paused", "p", false, "Select if you want to get paused databases.")
+	listDatabaseCmd.Flags().StringArray("filter", []string{}, "Only list workspaces matching key=value, ie: account=acme, free-tier=true, created-after=30d")
+	listDatabaseCmd.Flags().String("sort-by", "", "Sort the workspaces by a field, ie: name, status, account, created")
+	common.AddWatchFlags(listDatabaseCmd)
 }
@@ -0,0 +1,72 @@
+package list
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+)
+
+// filterKeys - the --filter keys besides the objects.DatabaseFields
+var filterKeys = []string{"created-after", "created-before"}
+
+// databaseFilter - the test for one --filter key=value, account matches the
+// account name or id, the created-before/after values are dates or ages
+// like 30d
+func databaseFilter(spec string, now time.Time) (func(objects.CMClusterInfo) bool, error) {
+	parts := strings.SplitN(spec, "=", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return nil, fmt.Errorf("--filter expects key=value, got '%s'", spec)
+	}
+	key, value := strings.ToLower(parts[0]), parts[1]
+
+	switch key {
+	case "account":
+		return func(cmci objects.CMClusterInfo) bool {
+			return strings.EqualFold(cmci.Account.AccountName, value) || strings.EqualFold(cmci.Account.AccountId, value)
+		}, nil
+	case "free-tier":
+		want, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("--filter free-tier expects true or false, got '%s'", value)
+		}
+		return func(cmci objects.CMClusterInfo) bool { return cmci.FreeTier() == want }, nil
+	case "created-before", "created-after":
+		at, err := common.ParseSince(value, now)
+		if err != nil {
+			return nil, fmt.Errorf("--filter %s: %w", key, err)
+		}
+		before := key == "created-before"
+		return func(cmci objects.CMClusterInfo) bool {
+			created, err := cmci.Created()
+			if err != nil {
+				return false
+			}
+			return created.Before(at) == before
+		}, nil
+	}
+
+	field, ok := objects.DatabaseField(key)
+	if !ok {
+		keys := append(objects.DatabaseFieldNames(), filterKeys...)
+		return nil, fmt.Errorf("can not filter on '%s', valid keys are [%s]", key, strings.Join(keys, "|"))
+	}
+	return func(cmci objects.CMClusterInfo) bool {
+		return strings.EqualFold(objects.DatabaseFields[field](cmci), value)
+	}, nil
+}
+
+// filterDatabases - the workspaces matching every filter
+func filterDatabases(dbList *objects.DatabaseList, filters []func(objects.CMClusterInfo) bool) *objects.DatabaseList {
+	return dbList.GroupBy(func(cmci objects.CMClusterInfo) bool {
+		for _, test := range filters {
+			if !test(cmci) {
+				return false
+			}
+		}
+		return true
+	})
+}
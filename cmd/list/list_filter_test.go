@@ -0,0 +1,100 @@
+package list
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/printers"
+)
+
+func testDatabases() *objects.DatabaseList {
+	cluster := func(name, account, status, created string, freeTier bool) objects.CMClusterInfo {
+		return objects.CMClusterInfo{
+			DcosAppId: name,
+			Status:    status,
+			CreatedAt: created,
+			Account:   objects.CMAccount{AccountName: account, AccountId: account + "-id"},
+			ClusterConfigurations: objects.ClusterConfigurationList{
+				{FreeTier: !freeTier, EffectiveEndDate: "2021-01-01T00:00:00Z"},
+				{FreeTier: freeTier},
+			},
+		}
+	}
+	return &objects.DatabaseList{Clusters: []objects.CMClusterInfo{
+		cluster("zeta", "acme", "ACTIVE", "2021-02-10T10:00:00.000Z", true),
+		cluster("alpha", "acme", "PAUSED", "2021-01-05T10:00:00.000Z", true),
+		cluster("beta", "other", "ACTIVE", "2021-02-12T10:00:00.000Z", true),
+		cluster("gamma", "acme", "ACTIVE", "2021-02-15T10:00:00.000Z", false),
+	}}
+}
+
+func TestFilterDatabases(t *testing.T) {
+	now := time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+	}{
+		{name: "none", want: []string{"zeta", "alpha", "beta", "gamma"}},
+		{name: "account by id", filters: []string{"account=acme-id"}, want: []string{"zeta", "alpha", "gamma"}},
+		{name: "status", filters: []string{"status=active"}, want: []string{"zeta", "beta", "gamma"}},
+		{
+			name:    "free tier for an account created last month",
+			filters: []string{"account=acme", "free-tier=true", "created-after=2021-02-01", "created-before=2021-03-01"},
+			want:    []string{"zeta"},
+		},
+		{name: "created by age", filters: []string{"created-after=40d"}, want: []string{"zeta", "beta", "gamma"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters := make([]func(objects.CMClusterInfo) bool, 0)
+			for _, spec := range tt.filters {
+				filter, err := databaseFilter(spec, now)
+				if err != nil {
+					t.Fatalf("databaseFilter(%s) error = %v", spec, err)
+				}
+				filters = append(filters, filter)
+			}
+			got := make([]string, 0)
+			for _, cmci := range filterDatabases(testDatabases(), filters).Clusters {
+				got = append(got, cmci.DcosAppId)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, spec := range []string{"account", "colour=red", "free-tier=maybe", "created-after=someday"} {
+		if _, err := databaseFilter(spec, now); err == nil {
+			t.Errorf("databaseFilter(%s) want an error", spec)
+		}
+	}
+}
+
+func TestSortAndWide(t *testing.T) {
+	sorted, err := testDatabases().SortBy("DATABASE")
+	if err != nil {
+		t.Fatalf("SortBy() error = %v", err)
+	}
+	if sorted.Clusters[0].DcosAppId != "alpha" || sorted.Clusters[3].DcosAppId != "zeta" {
+		t.Errorf("SortBy(DATABASE) = %v", sorted.Clusters)
+	}
+	if _, err := testDatabases().SortBy("colour"); err == nil {
+		t.Error("SortBy(colour) want an error")
+	}
+
+	table, err := printers.TableOf(sorted, printers.Options{Wide: true})
+	if err != nil {
+		t.Fatalf("TableOf() error = %v", err)
+	}
+	want := "DATABASE NAMESPACE STATUS CLUSTER_ID ACCOUNT EMAIL FREE_TIER CREATED UPDATED"
+	if got := strings.Join(table.Header, " "); got != want {
+		t.Errorf("wide header = %s, want %s", got, want)
+	}
+	if table.Rows[0][6] != "true" || table.Rows[3][4] != "acme" {
+		t.Errorf("wide row = %v", table.Rows[0])
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -12,27 +13,36 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/splicemachine/splicectl/auth"
 	"github.com/splicemachine/splicectl/cmd/apply"
+	"github.com/splicemachine/splicectl/cmd/audit"
+	"github.com/splicemachine/splicectl/cmd/cache"
 	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/contexts"
 	"github.com/splicemachine/splicectl/cmd/create"
 	"github.com/splicemachine/splicectl/cmd/del"
+	"github.com/splicemachine/splicectl/cmd/diff"
+	"github.com/splicemachine/splicectl/cmd/edit"
 	"github.com/splicemachine/splicectl/cmd/get"
 	"github.com/splicemachine/splicectl/cmd/list"
+	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/cmd/restart"
 	"github.com/splicemachine/splicectl/cmd/rollback"
+	"github.com/splicemachine/splicectl/cmd/ui"
 	"github.com/splicemachine/splicectl/cmd/version"
 	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 )
 
 var (
-	semVer    string
-	gitCommit string
-	buildDate string
-	gitRef    string
-	cfgFile   string
-	serverURI string
+	semVer      string
+	gitCommit   string
+	buildDate   string
+	gitRef      string
+	cfgFile     string
+	serverURI   string
+	contextName string
 
 	// semVerReg - gets the semVer portion only, cutting off any other release details
 	semVerReg = regexp.MustCompile(`(v[0-9]+\.[0-9]+\.[0-9]+).*`)
@@ -51,42 +61,57 @@ var RootCmd = &cobra.Command{
 database clusters under Kubernetes easier to manage.`,
 	Args: cobra.MinimumNArgs(1),
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if len(c.CACert) > 0 {
-			if _, err := os.Stat(c.CACert); err != nil {
-				if os.IsNotExist(err) {
-					logrus.Info("Couldn't read the ca-file, please check the path")
-					os.Exit(1)
-				}
+		// Validate global parameters here, BEFORE we start to waste time
+		// and run any code.
+		if c.OutputFormat != "" {
+			if err := printers.Validate(c.OutputFormat); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
 			}
-			fileBytes, _ := ioutil.ReadFile(c.CACert)
-			c.CABundle = strings.TrimSpace(string(fileBytes[:]))
+			c.FormatOverridden = true
 		} else {
-			c.CACert = os.Getenv("SPLICECTL_CACERT")
-			if len(c.CACert) > 0 {
-				if _, err := os.Stat(c.CACert); err != nil {
-					if os.IsNotExist(err) {
-						logrus.Info("Couldn't read the ca-file, please check the path")
-						os.Exit(1)
-					}
-				}
-			}
-			fileBytes, _ := ioutil.ReadFile(c.CACert)
-			c.CABundle = strings.TrimSpace(string(fileBytes[:]))
+			c.FormatOverridden = false
+			c.OutputFormat = "json"
 		}
 
-		c.ApiServer = getIngressDetail()
-		if len(serverURI) > 0 {
-			c.ApiServer = serverURI
+		// Prompts would hang a CI job waiting for input that never comes
+		if !c.NonInteractive && !common.IsTerminal(os.Stdin) {
+			c.NonInteractive = true
+		}
+
+		// Managing contexts, reading the audit log and generating completion
+		// scripts only touch local files, completions connect on demand
+		switch topLevelName(cmd) {
+		case "audit", "context", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return
+		}
+
+		if err := c.UseContext(contextName); err != nil {
+			logrus.WithError(err).Fatal("Could not select the context")
 		}
+		c.UseCache()
+
+		// Clearing the cache only needs the context
+		if topLevelName(cmd) == "cache" {
+			return
+		}
+
+		selectServer()
 
 		// Collect the version info, for use in determining valid commands based on SemVer
 		if c.ApiServer != "" {
-			version, err := c.GetVersionInfo()
+			version, err := serverVersion(cmd)
 			if err != nil {
+				// 'version' still reports the client side when the server is unreachable
+				if topLevelName(cmd) != "version" {
+					c.FatalError(err, "Error getting version info")
+				}
 				logrus.WithError(err).Error("Error getting version info")
+				version = &objects.BaseVersion{}
 			}
+			serverJSON, _ := json.Marshal(version)
 			clientLine := fmt.Sprintf("\"Client\": {\"SemVer\": \"%s\", \"GitCommit\": \"%s\", \"BuildDate\": \"%s\"},", semVer, gitCommit, buildDate)
-			serverLine := fmt.Sprintf("\"Server\": %s},", version)
+			serverLine := fmt.Sprintf("\"Server\": %s},", serverJSON)
 			hostLine := fmt.Sprintf("\"Host\": \"%s\"", c.ApiServer)
 			c.VersionJSON = fmt.Sprintf("{\"VersionInfo\": {\n%s\n%s\n%s\n}", clientLine, serverLine, hostLine)
 		} else {
@@ -98,43 +123,89 @@ database clusters under Kubernetes easier to manage.`,
 			logrus.WithError(err).Error("Error decoding json for Version")
 		}
 
-		if os.Args[1] != "version" {
+		if topLevelName(cmd) != "version" {
 			environment := getEnvironmentName()
-			c.AuthClient = auth.NewAuth(environment, common.SessionData{
-				SessionID:  fmt.Sprintf("%s", viper.Get(fmt.Sprintf("%s-session_id", environment))),
-				ValidUntil: fmt.Sprintf("%s", viper.Get(fmt.Sprintf("%s-valid_until", environment))),
-			})
+			c.Environment = environment
+			c.AuthClient = auth.NewAuth(environment, c.SessionFor(environment))
 			isValid := c.AuthClient.CheckTokenValidity()
-			if !isValid && os.Args[1] != "auth" {
-				logrus.Info("Your session has expired, please run the 'auth' again.")
-				os.Exit(1)
+			if !isValid && topLevelName(cmd) != "auth" {
+				c.FatalError(config.ErrSessionExpired, "Could not authenticate")
+			}
+			if mutatingCommand(cmd) {
+				command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+				c.StartAudit(command, common.AuditArgs(cmd, args), auditDatabase(cmd), environment)
 			}
 		}
 
-		// Validate global parameters here, BEFORE we start to waste time
-		// and run any code.
-		if c.OutputFormat != "" {
-			c.OutputFormat = strings.ToLower(c.OutputFormat)
-			switch c.OutputFormat {
-			case "json", "gron", "yaml", "text", "table", "raw":
-				break
-			default:
-				fmt.Println("Valid options for -o are [json|gron|[text|table]|yaml|raw]")
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		c.FinishAudit(0, nil)
+	},
+}
+
+// serverVersion - the version of the API server, cached for
+// config.VersionTTL except for 'version' which always asks the server
+func serverVersion(cmd *cobra.Command) (*objects.BaseVersion, error) {
+	key := "version-" + c.ApiServer
+	version := &objects.BaseVersion{}
+	if topLevelName(cmd) != "version" && c.CacheGet(key, config.VersionTTL, version) {
+		return version, nil
+	}
+	version, err := c.APIClient().ServerVersion(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	c.CachePut(key, version)
+	return version, nil
+}
+
+// selectServer - the CA bundle and the API server of the selected context,
+// the --cacert and --server-uri flags take precedence
+func selectServer() {
+	if len(c.CACert) > 0 {
+		if _, err := os.Stat(c.CACert); err != nil {
+			if os.IsNotExist(err) {
+				logrus.Info("Couldn't read the ca-file, please check the path")
 				os.Exit(1)
 			}
-			c.FormatOverridden = true
-		} else {
-			c.FormatOverridden = false
-			c.OutputFormat = "json"
 		}
-	},
+		fileBytes, _ := ioutil.ReadFile(c.CACert)
+		c.CABundle = strings.TrimSpace(string(fileBytes[:]))
+	} else {
+		c.CACert = os.Getenv("SPLICECTL_CACERT")
+		if len(c.CACert) == 0 && c.Context != nil {
+			c.CACert = c.Context.CACert
+		}
+		if len(c.CACert) > 0 {
+			if _, err := os.Stat(c.CACert); err != nil {
+				if os.IsNotExist(err) {
+					logrus.Info("Couldn't read the ca-file, please check the path")
+					os.Exit(1)
+				}
+			}
+		}
+		fileBytes, _ := ioutil.ReadFile(c.CACert)
+		c.CABundle = strings.TrimSpace(string(fileBytes[:]))
+	}
+
+	if len(serverURI) == 0 && c.Context != nil {
+		serverURI = c.Context.ServerURI
+	}
+	if len(serverURI) > 0 {
+		c.ApiServer = serverURI
+	} else {
+		c.ApiServer = getIngressDetail()
+	}
 }
 
 func buildRootCmd() *cobra.Command {
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.splicectl/config.yml)")
+	RootCmd.PersistentFlags().StringVar(&contextName, "context", "", "the named context to use, see 'splicectl context list'")
 	RootCmd.PersistentFlags().StringVar(&serverURI, "server-uri", "", "override the server uri for the API server http(s)://host.domain.name:overrideport")
-	RootCmd.PersistentFlags().StringVarP(&c.OutputFormat, "output", "o", "", "output types: json, text, yaml, gron")
+	RootCmd.PersistentFlags().StringVarP(&c.OutputFormat, "output", "o", "", fmt.Sprintf("output types: %s", strings.Join(printers.Formats(), ", ")))
 	RootCmd.PersistentFlags().BoolVar(&c.NoHeaders, "no-headers", false, "Suppress header output in Text output")
+	RootCmd.PersistentFlags().BoolVar(&c.NonInteractive, "non-interactive", false, "Never prompt, a missing required input is an error (the default when stdin is not a terminal)")
+	RootCmd.PersistentFlags().BoolVar(&c.NoCache, "no-cache", false, "Ask the cluster and the API server again instead of using answers cached under ~/.splicectl/cache")
 	RootCmd.PersistentFlags().StringVar(&c.CACert, "cacert", "", "Specify a cacert file to use to authenticate the SSL certificate")
 
 	return RootCmd
@@ -143,12 +214,18 @@ func buildRootCmd() *cobra.Command {
 func addSubcommands() {
 	RootCmd.AddCommand(
 		apply.InitSubCommands(c),
+		audit.InitSubCommands(c),
+		cache.InitSubCommands(c),
+		contexts.InitSubCommands(c),
 		create.InitSubCommands(c),
 		del.InitSubCommands(c),
+		diff.InitSubCommands(c),
+		edit.InitSubCommands(c),
 		get.InitSubCommands(c),
 		list.InitSubCommands(c),
 		restart.InitSubCommands(c),
 		rollback.InitSubCommands(c),
+		ui.InitSubCommands(c),
 		version.InitSubCommands(c),
 	)
 }
@@ -156,6 +233,10 @@ func addSubcommands() {
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// the commands of this package add their flags in init, so the tree is
+	// only complete once main runs
+	registerCompletions(RootCmd)
+	registerArgCompletions()
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -174,7 +255,7 @@ func initConfig() {
 		// Use config file from the flag.
 		if _, err := os.Stat(cfgFile); err != nil {
 			if os.IsNotExist(err) {
-				if os.Args[1] != "auth" {
+				if !configOptional() {
 					logrus.Info("Couldn't read the config file.  We require a session ID from the splicectl API.  Please run with 'auth'.")
 					os.Exit(1)
 				} else {
@@ -211,13 +292,71 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
-		if os.Args[1] != "auth" {
+		if !configOptional() {
 			logrus.Info("Couldn't read the config file.  We require a session ID from the splicectl API.  Please run with 'auth'.")
 			os.Exit(1)
 		}
 	}
 }
 
+// topLevelName - the name of the child of the root command that cmd belongs to
+func topLevelName(cmd *cobra.Command) string {
+	if cmd == nil || !cmd.HasParent() {
+		return ""
+	}
+	for cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd.Name()
+}
+
+// mutatingCommand - commands that change something on the cluster, these
+// are recorded in the audit log.  --dry-run and --skel only show what would
+// be sent, so they are not.
+func mutatingCommand(cmd *cobra.Command) bool {
+	for _, name := range []string{"dry-run", "skel"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() == "true" {
+			return false
+		}
+	}
+	switch topLevelName(cmd) {
+	case "apply", "create", "delete", "edit", "pause", "restart", "resume", "rollback":
+		return true
+	}
+	return false
+}
+
+// auditDatabase - the database named on the command line, without the
+// warnings common.DatabaseName gives when several aliases are used
+func auditDatabase(cmd *cobra.Command) string {
+	for _, name := range []string{"database-name", "workspace", "database"} {
+		if f := cmd.Flags().Lookup(name); f != nil && len(f.Value.String()) > 0 {
+			return f.Value.String()
+		}
+	}
+	return ""
+}
+
+// commandName - the name of the top level command being run, global flags
+// may appear before it so os.Args[1] can't be trusted.
+func commandName() string {
+	cmd, _, err := RootCmd.Find(os.Args[1:])
+	if err != nil {
+		return ""
+	}
+	return topLevelName(cmd)
+}
+
+// configOptional - commands that are able to run before 'auth' has written
+// the config file.
+func configOptional() bool {
+	switch commandName() {
+	case "audit", "auth", "cache", "context", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	return false
+}
+
 func createRestrictedConfigFile(fileName string) {
 	if _, err := os.Stat(fileName); err != nil {
 		if os.IsNotExist(err) {
@@ -237,7 +376,8 @@ func createRestrictedConfigFile(fileName string) {
 // ClientSemVer - returns the full semVer as the first string and the numerical
 // portion as the second string, they may be identical. One example where they
 // would not be is:
-//         semVer: v0.1.1-cacert -> (v0.1.1-cacert, v0.1.1).
+//
+//	semVer: v0.1.1-cacert -> (v0.1.1-cacert, v0.1.1).
 func ClientSemVer() (string, string) {
 	submatches := semVerReg.FindStringSubmatch(semVer)
 	if submatches == nil || len(submatches) < 2 {
@@ -0,0 +1,26 @@
+package objects
+
+import "time"
+
+// AuditRecord - one mutating command run from this workstation, Results are
+// the responses of the API calls that changed something, ie: the
+// VaultVersion or ActionStatus.
+type AuditRecord struct {
+	Timestamp   time.Time     `json:"timestamp" yaml:"timestamp" table:"TIMESTAMP"`
+	User        string        `json:"user,omitempty" yaml:"user,omitempty" table:"USER"`
+	Environment string        `json:"environment,omitempty" yaml:"environment,omitempty" table:"ENVIRONMENT"`
+	Context     string        `json:"context,omitempty" yaml:"context,omitempty"`
+	APIHost     string        `json:"apiHost,omitempty" yaml:"apiHost,omitempty"`
+	SessionHash string        `json:"sessionHash,omitempty" yaml:"sessionHash,omitempty"`
+	Command     string        `json:"command" yaml:"command" table:"COMMAND"`
+	Args        []string      `json:"args" yaml:"args" table:"ARGS,order=1"`
+	Database    string        `json:"database,omitempty" yaml:"database,omitempty" table:"DATABASE"`
+	Results     []interface{} `json:"results,omitempty" yaml:"results,omitempty"`
+	ExitStatus  int           `json:"exitStatus" yaml:"exitStatus" table:"EXIT"`
+	Error       string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// AuditRecordList - records read back from the audit log, oldest first
+type AuditRecordList struct {
+	Records []AuditRecord `json:"records" yaml:"records" table:",rows"`
+}
@@ -0,0 +1,38 @@
+package objects
+
+// Bulk results
+const (
+	BulkDone    = "done"
+	BulkFailed  = "failed"
+	BulkSkipped = "skipped"
+	BulkNotRun  = "not run"
+)
+
+// BulkResult - what an action selected by --all-active, --account-id,
+// --name-regex or --from-file did to one workspace
+type BulkResult struct {
+	Database string `json:"database" yaml:"database" table:"DATABASE"`
+	Status   string `json:"status" yaml:"status" table:"STATUS"`
+	Account  string `json:"account,omitempty" yaml:"account,omitempty" table:"ACCOUNT"`
+	Result   string `json:"result" yaml:"result" table:"RESULT"`
+	Process  string `json:"process,omitempty" yaml:"process,omitempty" table:"PROCESS,omitempty"`
+	Detail   string `json:"detail,omitempty" yaml:"detail,omitempty" table:"DETAIL,omitempty"`
+	Duration string `json:"duration,omitempty" yaml:"duration,omitempty" table:"DURATION,omitempty"`
+}
+
+// BulkResultList - the results of a bulk action, in the order of the
+// workspace names
+type BulkResultList struct {
+	Results []BulkResult `json:"results" yaml:"results" table:",rows"`
+}
+
+// Count - the results that ended as result
+func (brl *BulkResultList) Count(result string) int {
+	n := 0
+	for _, r := range brl.Results {
+		if r.Result == result {
+			n++
+		}
+	}
+	return n
+}
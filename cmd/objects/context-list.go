@@ -0,0 +1,51 @@
+package objects
+
+import "github.com/splicemachine/splicectl/printers"
+
+// ContextList - the named contexts stored in the splicectl config file
+type ContextList struct {
+	Current  string         `json:"current-context" yaml:"current-context"`
+	Contexts []NamedContext `json:"contexts" yaml:"contexts"`
+}
+
+// NamedContext - everything needed to reach one cluster/environment
+type NamedContext struct {
+	Name        string         `json:"name" yaml:"name" mapstructure:"name"`
+	KubeConfig  string         `json:"kubeconfig,omitempty" yaml:"kubeconfig,omitempty" mapstructure:"kubeconfig"`
+	KubeContext string         `json:"kube-context,omitempty" yaml:"kube-context,omitempty" mapstructure:"kube-context"`
+	ServerURI   string         `json:"server-uri,omitempty" yaml:"server-uri,omitempty" mapstructure:"server-uri"`
+	CACert      string         `json:"cacert,omitempty" yaml:"cacert,omitempty" mapstructure:"cacert"`
+	Namespace   string         `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace"`
+	Ingress     string         `json:"ingress,omitempty" yaml:"ingress,omitempty" mapstructure:"ingress"`
+	Session     ContextSession `json:"session" yaml:"session,omitempty" mapstructure:"session"`
+}
+
+// ContextSession - the auth session obtained for a named context
+type ContextSession struct {
+	SessionID  string `json:"session_id,omitempty" yaml:"session_id,omitempty" mapstructure:"session_id"`
+	ValidUntil string `json:"valid_until,omitempty" yaml:"valid_until,omitempty" mapstructure:"valid_until"`
+}
+
+// Redacted - a copy of the list that is safe to display, session ids are
+// credentials and never leave the config file.
+func (cl *ContextList) Redacted() interface{} {
+	out := &ContextList{Current: cl.Current, Contexts: make([]NamedContext, 0, len(cl.Contexts))}
+	for _, v := range cl.Contexts {
+		v.Session.SessionID = ""
+		out.Contexts = append(out.Contexts, v)
+	}
+	return out
+}
+
+// Table - the contexts, the current one is marked with a '*'
+func (cl *ContextList) Table(opts printers.Options) *printers.Table {
+	table := &printers.Table{Header: []string{"CURRENT", "NAME", "KUBE_CONTEXT", "SERVER_URI", "NAMESPACE", "SESSION_VALID_UNTIL"}}
+	for _, v := range cl.Contexts {
+		current := ""
+		if v.Name == cl.Current {
+			current = "*"
+		}
+		table.Append(current, v.Name, v.KubeContext, v.ServerURI, v.Namespace, v.Session.ValidUntil)
+	}
+	return table
+}
@@ -1,19 +1,17 @@
 package objects
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
-	"github.com/maahsome/gron"
-	"github.com/olekukonko/tablewriter"
 	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
+	"github.com/splicemachine/splicectl/printers"
 )
 
+// DatabaseCR - the database custom resource stored in Vault
 type DatabaseCR struct {
 	Data map[string]interface{} `json:"data"`
 }
@@ -65,110 +63,61 @@ type DatabaseCRList struct {
 	} `json:"data"`
 }
 
-// ToJSON - Write the output as JSON
-func (cr *DatabaseCR) ToJSON(file string) error {
-	crJSON, enverr := json.MarshalIndent(cr, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return enverr
-	} else {
-		if len(file) == 0 {
-			fmt.Println(string(crJSON[:]))
-		} else {
-			WriteToFile(file, string(crJSON[:]))
-		}
-	}
-
-	return nil
+// ComponentCondition - whether a component of the database is enabled
+type ComponentCondition struct {
+	Name    string
+	Enabled bool
 }
 
-// ToGRON - Write output in GRON format
-func (cr *DatabaseCR) ToGRON(file string) error {
-	crJSON, enverr := json.MarshalIndent(cr, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return enverr
-	}
-
-	subReader := strings.NewReader(string(crJSON[:]))
-	subValues := &bytes.Buffer{}
-	ges := gron.NewGron(subReader, subValues)
-	ges.SetMonochrome(false)
-	serr := ges.ToGron()
-	if serr != nil {
-		logrus.Error("Problem generating gron syntax", serr)
-		return serr
+// Components - the conditions of the CR, in the order of the table columns
+func (crl *DatabaseCRList) Components() []ComponentCondition {
+	condition := crl.Data.Spec.Condition
+	return []ComponentCondition{
+		{Name: "haproxy", Enabled: condition.Haproxy.Enabled},
+		{Name: "hbase", Enabled: condition.Hbase.Enabled},
+		{Name: "hdfs", Enabled: condition.Hdfs.Enabled},
+		{Name: "jupyterhub", Enabled: condition.JupyterHub.Enabled},
+		{Name: "jvmprofiler", Enabled: condition.JvmProfiler.Enabled},
+		{Name: "kafka", Enabled: condition.Kafka.Enabled},
+		{Name: "mlmanager", Enabled: condition.MlManager.Enabled},
+		{Name: "rbac", Enabled: condition.Rbac.Enabled},
+		{Name: "splice-http", Enabled: condition.SpliceHTTP.Enabled},
+		{Name: "zookeeper", Enabled: condition.Zookeeper.Enabled},
 	}
-	fmt.Println(string(subValues.Bytes()))
-
-	return nil
 }
 
-// ToYAML - Write the output as YAML
-func (cr *DatabaseCR) ToYAML(file string) error {
-
-	crYAML, enverr := yaml.Marshal(cr)
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting yaml")
-		return enverr
-	} else {
-		if len(file) == 0 {
-			fmt.Println(string(crYAML[:]))
-		} else {
-			WriteToFile(file, string(crYAML[:]))
-		}
+// List - the fields of the CR that splicectl reads
+func (cr *DatabaseCR) List() (*DatabaseCRList, error) {
+	crList := &DatabaseCRList{}
+	out, err := json.Marshal(cr)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-
+	if err := json.Unmarshal(out, crList); err != nil {
+		return nil, err
+	}
+	return crList, nil
 }
 
-// ToTEXT - Write the output as TEXT
-func (cr *DatabaseCR) ToTEXT(noHeaders bool) error {
-
-	var row []string
-
-	out, _ := json.Marshal(&cr)
-
-	var crList DatabaseCRList
-	marshErr := json.Unmarshal([]byte(out), &crList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
+// Table - the database and which of its components are enabled
+func (cr *DatabaseCR) Table(opts printers.Options) *printers.Table {
+	table := &printers.Table{Header: []string{"DATABASE", "NAMESPACE"}}
+	for _, component := range (&DatabaseCRList{}).Components() {
+		table.Header = append(table.Header, strings.ToUpper(component.Name))
 	}
 
-	// ******************** TableWriter *******************************
-	table := tablewriter.NewWriter(os.Stdout)
-	if !noHeaders {
-		table.SetHeader([]string{"DATABASE", "NAMESPACE", "HAPROXY", "HBASE", "HDFS", "JUPYTERHUB", "JVMPROFILER", "KAFKA", "MLMANAGER", "RBAC", "SPLICE-HTTP", "ZOOKEEPER"})
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	}
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("\t") // pad with tabs
-	table.SetNoWhiteSpace(true)
-	row = []string{crList.Data.Metadata.Name,
-		crList.Data.Spec.Global.Namespace,
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Haproxy.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Hbase.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Hdfs.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.JupyterHub.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.JvmProfiler.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Kafka.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.MlManager.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Rbac.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.SpliceHTTP.Enabled),
-		fmt.Sprintf("%t", crList.Data.Spec.Condition.Zookeeper.Enabled),
+	crList, err := cr.List()
+	if err != nil {
+		logrus.WithError(err).Error("Could not unmarshall data")
+		return table
 	}
-	table.Append(row)
-	table.Render()
-
-	return nil
 
+	row := []string{crList.Data.Metadata.Name, crList.Data.Spec.Global.Namespace}
+	for _, component := range crList.Components() {
+		row = append(row, fmt.Sprintf("%t", component.Enabled))
+	}
+	table.Append(row...)
+	return table
 }
 
 // WriteToFile will print any string of text to a file safely by
@@ -1,36 +1,36 @@
 package objects
 
 import (
-	"bytes"
-	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/maahsome/gron"
-	"github.com/olekukonko/tablewriter"
-	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
+	"time"
 )
 
 // DatabaseList - Data to read from the CM API
 type DatabaseList struct {
-	Clusters []CMClusterInfo `json:"clusters"`
+	Clusters []CMClusterInfo `json:"clusters" table:",rows"`
 }
 
 // CMClusterInfo - Cluster Info
 type CMClusterInfo struct {
-	CreatedAt             string                   `json:"createdAt"`
-	UpdatedAt             string                   `json:"updatedAt"`
+	CreatedAt             string                   `json:"createdAt" table:"CREATED,wide,order=8"`
+	UpdatedAt             string                   `json:"updatedAt" table:"UPDATED,wide,order=9"`
 	DeletedAt             string                   `json:"deletedAt"`
-	ClusterId             string                   `json:"clusterId"`
-	DcosAppId             string                   `json:"dcosAppId"`
+	ClusterId             string                   `json:"clusterId" table:"CLUSTER_ID,order=4"`
+	DcosAppId             string                   `json:"dcosAppId" table:"DATABASE,order=1"`
 	Name                  string                   `json:"name"`
-	Namespace             string                   `json:"namespace"`
-	Status                string                   `json:"status"`
-	ClusterConfigurations []CMClusterConfiguration `json:"clusterConfigurations"`
-	Account               CMAccount                `json:"account"`
-	User                  CMUser                   `json:"user"`
+	Namespace             string                   `json:"namespace" table:"NAMESPACE,order=2"`
+	Status                string                   `json:"status" table:"STATUS,order=3"`
+	ClusterConfigurations ClusterConfigurationList `json:"clusterConfigurations" table:"FREE_TIER,wide,order=7"`
+	Account               CMAccount                `json:"account" table:",inline"`
+	User                  CMUser                   `json:"user" table:",inline"`
 }
 
+// ClusterConfigurationList - the configurations of a cluster over time
+type ClusterConfigurationList []CMClusterConfiguration
+
 // CMClusterConfiguration - CM Cluster Config Info
 type CMClusterConfiguration struct {
 	CreatedAt          string `json:"createdAt"`
@@ -44,7 +44,7 @@ type CMClusterConfiguration struct {
 type CMAccount struct {
 	CreatedAt   string `json:"createdAt"`
 	UpdatedAt   string `json:"updatedAt"`
-	AccountName string `json:"accountName"`
+	AccountName string `json:"accountName" table:"ACCOUNT,wide,order=5"`
 	AccountId   string `json:"accountId"`
 }
 
@@ -53,7 +53,7 @@ type CMUser struct {
 	LastLogin string `json:"lastLogin"`
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updatedAt"`
-	Email     string `json:"email"`
+	Email     string `json:"email" table:"EMAIL,wide,order=6"`
 }
 
 const (
@@ -66,6 +66,112 @@ func (cmci CMClusterInfo) Active() bool {
 	return strings.ToLower(cmci.Status) == active
 }
 
+// Current - the configuration in effect, the one without an end date or
+// else the last one, nil when there are none
+func (ccl ClusterConfigurationList) Current() *CMClusterConfiguration {
+	for i := range ccl {
+		if len(ccl[i].EffectiveEndDate) == 0 {
+			return &ccl[i]
+		}
+	}
+	if len(ccl) > 0 {
+		return &ccl[len(ccl)-1]
+	}
+	return nil
+}
+
+// Cell - the FREE_TIER column, whether the configuration in effect is free
+// tier
+func (ccl ClusterConfigurationList) Cell() string {
+	if cfg := ccl.Current(); cfg != nil {
+		return strconv.FormatBool(cfg.FreeTier)
+	}
+	return ""
+}
+
+// Created - when the workspace was created
+func (cmci CMClusterInfo) Created() (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"} {
+		if t, err := time.Parse(layout, cmci.CreatedAt); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unknown createdAt format '%s'", cmci.CreatedAt)
+}
+
+// FreeTier - whether the workspace is on the free tier
+func (cmci CMClusterInfo) FreeTier() bool {
+	cfg := cmci.ClusterConfigurations.Current()
+	return cfg != nil && cfg.FreeTier
+}
+
+// DatabaseFields - the fields 'list workspace' sorts and filters on, the
+// timestamps sort as text since the API writes them all in one format
+var DatabaseFields = map[string]func(CMClusterInfo) string{
+	"name":       func(cmci CMClusterInfo) string { return cmci.DcosAppId },
+	"namespace":  func(cmci CMClusterInfo) string { return cmci.Namespace },
+	"status":     func(cmci CMClusterInfo) string { return cmci.Status },
+	"cluster-id": func(cmci CMClusterInfo) string { return cmci.ClusterId },
+	"account":    func(cmci CMClusterInfo) string { return cmci.Account.AccountName },
+	"email":      func(cmci CMClusterInfo) string { return cmci.User.Email },
+	"free-tier":  func(cmci CMClusterInfo) string { return strconv.FormatBool(cmci.FreeTier()) },
+	"created":    func(cmci CMClusterInfo) string { return cmci.CreatedAt },
+	"updated":    func(cmci CMClusterInfo) string { return cmci.UpdatedAt },
+}
+
+// DatabaseField - the DatabaseFields key for a name given on the command
+// line, the column headers are accepted as well, ie: CLUSTER_ID, DATABASE
+func DatabaseField(name string) (string, bool) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(name, ".")), "_", "-")
+	switch key {
+	case "database", "dcosappid":
+		key = "name"
+	case "clusterid":
+		key = "cluster-id"
+	case "createdat":
+		key = "created"
+	case "updatedat":
+		key = "updated"
+	}
+	_, ok := DatabaseFields[key]
+	return key, ok
+}
+
+// SortBy - orders the list on one of DatabaseFields, keeping the order of
+// equal entries
+func (dbl *DatabaseList) SortBy(field string) (*DatabaseList, error) {
+	key, ok := DatabaseField(field)
+	if !ok {
+		return nil, fmt.Errorf("can not sort by '%s', valid fields are [%s]", field, strings.Join(DatabaseFieldNames(), "|"))
+	}
+	value := DatabaseFields[key]
+	clusters := append([]CMClusterInfo{}, dbl.Clusters...)
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return value(clusters[i]) < value(clusters[j])
+	})
+	return &DatabaseList{Clusters: clusters}, nil
+}
+
+// DatabaseFieldNames - the keys of DatabaseFields, sorted
+func DatabaseFieldNames() []string {
+	names := make([]string, 0, len(DatabaseFields))
+	for k := range DatabaseFields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Find - the workspace with the given name (dcosAppId), or nil
+func (dbl *DatabaseList) Find(name string) *CMClusterInfo {
+	for i := range dbl.Clusters {
+		if dbl.Clusters[i].DcosAppId == name {
+			return &dbl.Clusters[i]
+		}
+	}
+	return nil
+}
+
 // GroupBy - filters the list on a given test
 func (dbl *DatabaseList) GroupBy(test func(CMClusterInfo) bool) *DatabaseList {
 	newDbl := make([]CMClusterInfo, 0)
@@ -91,71 +197,3 @@ func (dbl *DatabaseList) FilterByStatus(active, paused bool) *DatabaseList {
 		return dbl
 	}
 }
-
-// ToJSON - Write the output as JSON
-func (databaseList *DatabaseList) ToJSON() string {
-	dblJSON, enverr := json.MarshalIndent(databaseList, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-	return string(dblJSON[:])
-}
-
-// ToGRON - Write the output as GRON
-func (databaseList *DatabaseList) ToGRON() string {
-	dbJSON, enverr := json.MarshalIndent(databaseList, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-
-	subReader := strings.NewReader(string(dbJSON[:]))
-	subValues := &bytes.Buffer{}
-	ges := gron.NewGron(subReader, subValues)
-	ges.SetMonochrome(false)
-	if serr := ges.ToGron(); serr != nil {
-		logrus.Error("Problem generating gron syntax", serr)
-		return ""
-	}
-	return string(subValues.Bytes())
-}
-
-// ToYAML - Write the output as YAML
-func (databaseList *DatabaseList) ToYAML() string {
-	dblYAML, enverr := yaml.Marshal(databaseList)
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting yaml")
-		return ""
-	}
-	return string(dblYAML[:])
-}
-
-// ToText - Write the output as Text
-func (databaseList *DatabaseList) ToText(noHeaders bool) string {
-	buf, row := new(bytes.Buffer), make([]string, 0)
-
-	// ******************** TableWriter *******************************
-	table := tablewriter.NewWriter(buf)
-	if !noHeaders {
-		table.SetHeader([]string{"DATABASE", "NAMESPACE", "STATUS", "CLUSTER_ID"})
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	}
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("\t") // pad with tabs
-	table.SetNoWhiteSpace(true)
-	for _, v := range databaseList.Clusters {
-		row = []string{v.DcosAppId, v.Namespace, v.Status, v.ClusterId}
-		table.Append(row)
-	}
-	table.Render()
-
-	return buf.String()
-}
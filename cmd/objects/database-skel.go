@@ -1,12 +1,5 @@
 package objects
 
-import (
-	"encoding/json"
-
-	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
-)
-
 // DatabaseRequest - Cloud Manager Database Request
 type DatabaseRequest struct {
 	AccountID                    string `json:"accountId"`
@@ -30,23 +23,3 @@ type DatabaseRequest struct {
 	Password                     string `json:"password"`
 	// Region                       string `json:"region"`
 }
-
-// ToJSON - Write the output as JSON
-func (r *DatabaseRequest) ToJSON() string {
-	rJSON, enverr := json.MarshalIndent(r, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-	return string(rJSON[:])
-}
-
-// ToYAML - Write the output as YAML
-func (r *DatabaseRequest) ToYAML() string {
-	rYAML, enverr := yaml.Marshal(r)
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting yaml")
-		return ""
-	}
-	return string(rYAML[:])
-}
@@ -0,0 +1,5 @@
+package objects
+
+// DatabaseStatus - the status document the API server reports for a
+// workspace, its text output is a KEY/VALUE table
+type DatabaseStatus map[string]interface{}
@@ -0,0 +1,102 @@
+package objects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// Diff operations
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+)
+
+// DiffChange - a single difference between two documents, Path is in
+// JSONPath form, ie: .data.spec.hbase.replicas or .items[2]
+type DiffChange struct {
+	Path string      `json:"path" yaml:"path" table:"PATH"`
+	Op   string      `json:"op" yaml:"op" table:"OP"`
+	Old  interface{} `json:"old" yaml:"old" table:"OLD"`
+	New  interface{} `json:"new" yaml:"new" table:"NEW"`
+}
+
+// DiffChangeList - the differences between two documents, From and To name
+// the two sides.  FromVersion is the vault version From was read at, when
+// it was read from Vault.
+type DiffChangeList struct {
+	From        string       `json:"from,omitempty" yaml:"from,omitempty"`
+	To          string       `json:"to,omitempty" yaml:"to,omitempty"`
+	FromVersion int          `json:"fromVersion,omitempty" yaml:"fromVersion,omitempty"`
+	Changes     []DiffChange `json:"changes" yaml:"changes" table:",rows"`
+}
+
+// HasChanges - whether the two documents differ
+func (dl *DiffChangeList) HasChanges() bool {
+	return len(dl.Changes) > 0
+}
+
+// ToText - Write the output as a structural diff, one line per change,
+// colored when writing to a terminal
+func (dl *DiffChangeList) ToText(noHeaders bool) string {
+	buf := new(bytes.Buffer)
+	if !noHeaders && (len(dl.From) > 0 || len(dl.To) > 0) {
+		fmt.Fprintln(buf, color.New(color.Bold).Sprintf("--- %s", dl.From))
+		fmt.Fprintln(buf, color.New(color.Bold).Sprintf("+++ %s", dl.To))
+	}
+	if !dl.HasChanges() {
+		fmt.Fprintln(buf, "No differences")
+		return buf.String()
+	}
+	for _, ch := range dl.Changes {
+		switch ch.Op {
+		case DiffAdded:
+			fmt.Fprintln(buf, color.GreenString("+ %s: %s", ch.Path, diffValue(ch.New)))
+		case DiffRemoved:
+			fmt.Fprintln(buf, color.RedString("- %s: %s", ch.Path, diffValue(ch.Old)))
+		default:
+			fmt.Fprintln(buf, color.YellowString("~ %s: %s => %s", ch.Path, diffValue(ch.Old), diffValue(ch.New)))
+		}
+	}
+	return buf.String()
+}
+
+// diffValue - compact JSON for a value in a diff line
+func diffValue(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
+
+// ThreeWayDiff - the changes made on both sides since a common base version,
+// Conflicts are the paths changed by both
+type ThreeWayDiff struct {
+	Base      string          `json:"base" yaml:"base"`
+	Theirs    *DiffChangeList `json:"theirs" yaml:"theirs"`
+	Ours      *DiffChangeList `json:"ours" yaml:"ours"`
+	Conflicts []string        `json:"conflicts" yaml:"conflicts"`
+}
+
+// ToText - Write the output as the two diffs against the base followed by
+// the conflicting paths
+func (td *ThreeWayDiff) ToText(noHeaders bool) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprintln(buf, "Stored since the base version:")
+	fmt.Fprint(buf, td.Theirs.ToText(noHeaders))
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "Your changes:")
+	fmt.Fprint(buf, td.Ours.ToText(noHeaders))
+	if len(td.Conflicts) > 0 {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, color.RedString("Changed on both sides:"))
+		for _, path := range td.Conflicts {
+			fmt.Fprintf(buf, "  %s\n", path)
+		}
+	}
+	return buf.String()
+}
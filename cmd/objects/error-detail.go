@@ -0,0 +1,14 @@
+package objects
+
+// ErrorDetail - a failed command, rendered for scripts that asked for
+// structured output
+type ErrorDetail struct {
+	Error     string `json:"error" yaml:"error" table:"ERROR,order=5"`
+	Class     string `json:"class" yaml:"class" table:"CLASS,order=1"`
+	ExitCode  int    `json:"exitCode" yaml:"exitCode" table:"EXIT_CODE,order=2"`
+	Status    int    `json:"status,omitempty" yaml:"status,omitempty" table:"STATUS,order=3"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+	RequestID string `json:"requestId,omitempty" yaml:"requestId,omitempty" table:"REQUEST_ID,order=4"`
+	Method    string `json:"method,omitempty" yaml:"method,omitempty"`
+	Path      string `json:"path,omitempty" yaml:"path,omitempty"`
+}
@@ -0,0 +1,31 @@
+package objects
+
+import "time"
+
+// EventList - the Kubernetes events of a workspace namespace, oldest first
+type EventList struct {
+	Events []Event `json:"events" yaml:"events" table:",rows"`
+}
+
+// Event - a Kubernetes event, Object is kind/name of what it is about
+type Event struct {
+	LastSeen  time.Time `json:"lastSeen" yaml:"lastSeen" table:"LAST_SEEN"`
+	Type      string    `json:"type" yaml:"type" table:"TYPE"`
+	Reason    string    `json:"reason" yaml:"reason" table:"REASON"`
+	Object    string    `json:"object" yaml:"object" table:"OBJECT"`
+	Count     int32     `json:"count" yaml:"count" table:"COUNT"`
+	Message   string    `json:"message" yaml:"message" table:"MESSAGE"`
+	FirstSeen time.Time `json:"firstSeen" yaml:"firstSeen" table:"FIRST_SEEN,wide"`
+	Source    string    `json:"source,omitempty" yaml:"source,omitempty" table:"SOURCE,wide"`
+	Name      string    `json:"name" yaml:"name" table:"NAME,wide"`
+}
+
+// WatchKeys - events are watched by name, the time they were last seen
+// changes whenever they are seen again
+func (el *EventList) WatchKeys() []string {
+	keys := make([]string, 0, len(el.Events))
+	for _, event := range el.Events {
+		keys = append(keys, event.Name)
+	}
+	return keys
+}
@@ -0,0 +1,24 @@
+package objects
+
+// LogSummary - what get logs saved of one pod
+type LogSummary struct {
+	Pod    string `json:"pod" yaml:"pod" table:"POD"`
+	Logs   int    `json:"logs" yaml:"logs" table:"LOGS"`
+	Bytes  int64  `json:"bytes" yaml:"bytes" table:"BYTES"`
+	Errors int    `json:"errors" yaml:"errors" table:"ERRORS"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty" table:"ERROR,omitempty"`
+}
+
+// LogSummaryList - the pods get logs saved, in the order they were listed
+type LogSummaryList struct {
+	Pods []LogSummary `json:"pods" yaml:"pods" table:",rows"`
+}
+
+// Errors - the logs that could not be saved
+func (lsl *LogSummaryList) Errors() int {
+	n := 0
+	for _, p := range lsl.Pods {
+		n += p.Errors
+	}
+	return n
+}
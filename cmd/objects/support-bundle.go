@@ -0,0 +1,32 @@
+package objects
+
+// SupportBundle - the index of a support bundle, written into it as
+// manifest.yaml
+type SupportBundle struct {
+	Workspace string               `json:"workspace" yaml:"workspace"`
+	Namespace string               `json:"namespace" yaml:"namespace"`
+	Created   string               `json:"created" yaml:"created"`
+	Client    string               `json:"client" yaml:"client"`
+	Server    string               `json:"server" yaml:"server"`
+	Entries   []SupportBundleEntry `json:"entries" yaml:"entries" table:",rows"`
+}
+
+// SupportBundleEntry - a file of a support bundle and where it came from,
+// Error is set when it could not be collected
+type SupportBundleEntry struct {
+	Name   string `json:"name" yaml:"name" table:"NAME"`
+	Source string `json:"source" yaml:"source" table:"SOURCE"`
+	Bytes  int64  `json:"bytes" yaml:"bytes" table:"BYTES"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty" table:"ERROR,omitempty"`
+}
+
+// Errors - the entries that could not be collected
+func (sb *SupportBundle) Errors() int {
+	n := 0
+	for _, e := range sb.Entries {
+		if len(e.Error) > 0 {
+			n++
+		}
+	}
+	return n
+}
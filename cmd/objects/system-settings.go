@@ -1,105 +1,43 @@
 package objects
 
 import (
-	"bytes"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
-	"strings"
+	"sort"
 
-	"github.com/maahsome/gron"
-	"github.com/olekukonko/tablewriter"
-	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
+	"github.com/splicemachine/splicectl/printers"
 )
 
 // SystemSettings - Structure to hold data for the system-settings calls
 type SystemSettings struct {
 	Data map[string]string `json:"data"`
+	// Decode - show the base64 encoded credentials decoded in the table
+	Decode bool `json:"-" yaml:"-"`
 }
 
-// ToJSON - Write the output as JSON
-func (settings *SystemSettings) ToJSON() string {
-
-	settingsJSON, enverr := json.MarshalIndent(settings, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-	return string(settingsJSON[:])
-}
-
-// ToGRON - Write the output as GRON
-func (settings *SystemSettings) ToGRON() string {
-	settingsJSON, enverr := json.MarshalIndent(settings, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-
-	subReader := strings.NewReader(string(settingsJSON[:]))
-	subValues := &bytes.Buffer{}
-	ges := gron.NewGron(subReader, subValues)
-	ges.SetMonochrome(false)
-	if serr := ges.ToGron(); serr != nil {
-		logrus.Error("Problem generating gron syntax", serr)
-		return ""
-	}
-	return string(subValues.Bytes())
-}
-
-// ToYAML - Write the output as YAML
-func (settings *SystemSettings) ToYAML() string {
-	settingsYAML, enverr := yaml.Marshal(settings)
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting yaml")
-		return ""
+// Table - the settings as KEY/VALUE rows, sorted by key
+func (settings *SystemSettings) Table(opts printers.Options) *printers.Table {
+	table := &printers.Table{Header: []string{"KEY", "VALUE"}}
+	if settings.Decode {
+		table.Footer = []string{"* denotes a field where values were base64 decoded", ""}
 	}
-	return string(settingsYAML[:])
-}
-
-// ToText - Write the output as Text
-func (settings *SystemSettings) ToText(noHeaders bool, decode bool) string {
-	buf, row := new(bytes.Buffer), make([]string, 0)
-	// ******************** TableWriter *******************************
-	table := tablewriter.NewWriter(buf)
-	if !noHeaders {
-		table.SetHeader([]string{"KEY", "VALUE"})
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	keys := make([]string, 0, len(settings.Data))
+	for k := range settings.Data {
+		keys = append(keys, k)
 	}
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("\t") // pad with tabs
-	table.SetNoWhiteSpace(true)
-	if decode {
-		table.SetFooter([]string{"* denotes a field where values were base64 decoded", ""})
-	}
-	for k, v := range settings.Data {
-		if decode {
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := settings.Data[k]
+		if settings.Decode {
 			switch k {
 			case "POSTGRES_BACKUP_AZURE_ACCOUNT_NAME", "POSTGRES_BACKUP_AZURE_ACCOUNT_KEY", "POSTGRES_BACKUP_AWS_SECRET_ACCESS_KEY", "POSTGRES_PASSWORD", "POSTGRES_USER":
-				data, wasEncoded := base64.StdEncoding.DecodeString(v)
-				if wasEncoded == nil {
-					row = []string{fmt.Sprintf("%s *", k), string(data)}
-				} else {
-					row = []string{k, v}
+				if data, err := base64.StdEncoding.DecodeString(v); err == nil {
+					table.Append(fmt.Sprintf("%s *", k), string(data))
+					continue
 				}
-			default:
-				row = []string{k, v}
 			}
-		} else {
-			row = []string{k, v}
 		}
-		table.Append(row)
-
+		table.Append(k, v)
 	}
-	table.Render()
-
-	return buf.String()
+	return table
 }
@@ -1,16 +1,12 @@
 package objects
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/blang/semver/v4"
-	"github.com/maahsome/gron"
-	"github.com/olekukonko/tablewriter"
 	"github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v2"
+	"github.com/splicemachine/splicectl/printers"
 )
 
 // ApplyCmSettings - lookup names
@@ -24,8 +20,19 @@ var CommandVersions = map[string]string{
 	"apply_image-tag":          "0.0.16",
 	"apply_system-settings":    "0.0.14",
 	"apply_vault-key":          "0.0.14",
+	"apply_workspace":          "0.1.7",
 	"create_database":          "0.1.7",
 	"delete":                   "0.1.7",
+	"diff_cm-settings":         "0.1.6",
+	"diff_database-cr":         "0.0.17",
+	"diff_default-cr":          "0.0.17",
+	"diff_system-settings":     "0.0.17",
+	"diff_vault-key":           "0.0.17",
+	"edit_cm-settings":         "0.1.6",
+	"edit_database-cr":         "0.0.17",
+	"edit_default-cr":          "0.0.17",
+	"edit_system-settings":     "0.0.17",
+	"edit_vault-key":           "0.0.17",
 	"get_accounts":             "0.1.7",
 	"get_cm-settings":          "0.1.6",
 	"get_database-cr":          "0.0.14",
@@ -43,6 +50,8 @@ var CommandVersions = map[string]string{
 	"rollback_default-cr":      "0.0.15",
 	"rollback_system-settings": "0.0.15",
 	"rollback_vault-key":       "0.0.15",
+	"support-bundle":           "0.1.6",
+	"ui":                       "0.1.7",
 	"versions_cm-settings":     "0.1.6",
 	"versions_database-cr":     "0.0.15",
 	"versions_default-cr":      "0.0.15",
@@ -86,71 +95,10 @@ func (v *Version) RequirementMet(command string) (semver.Version, semver.Version
 	return semver.Version{}, semver.Version{}
 }
 
-// ToJSON - Write the output as JSON
-func (v *Version) ToJSON() string {
-	versionJSON, enverr := json.MarshalIndent(v, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-	return string(versionJSON[:])
-}
-
-// ToGRON - Write the output as GRON
-func (v *Version) ToGRON() string {
-	verJSON, enverr := json.MarshalIndent(v, "", "  ")
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting json")
-		return ""
-	}
-
-	subReader := strings.NewReader(string(verJSON[:]))
-	subValues := &bytes.Buffer{}
-	ges := gron.NewGron(subReader, subValues)
-	ges.SetMonochrome(false)
-	if serr := ges.ToGron(); serr != nil {
-		logrus.Error("Problem generating gron syntax", serr)
-		return ""
-	}
-	return string(subValues.Bytes())
-}
-
-// ToYAML - Write the output as YAML
-func (v *Version) ToYAML() string {
-	versionYAML, enverr := yaml.Marshal(v)
-	if enverr != nil {
-		logrus.WithError(enverr).Error("Error extracting yaml")
-		return ""
-	}
-	return string(versionYAML[:])
-}
-
-// ToText - Write the output as Text
-func (v *Version) ToTEXT(noHeaders bool) string {
-	buf, row := new(bytes.Buffer), make([]string, 0)
-
-	// ******************** TableWriter *******************************
-	table := tablewriter.NewWriter(buf)
-	if !noHeaders {
-		table.SetHeader([]string{"COMPONENT", "VERSION"})
-		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	}
-	table.SetAutoWrapText(false)
-	table.SetAutoFormatHeaders(true)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("")
-	table.SetRowSeparator("")
-	table.SetHeaderLine(false)
-	table.SetBorder(false)
-	table.SetTablePadding("\t") // pad with tabs
-	table.SetNoWhiteSpace(true)
-	row = []string{"Client", v.VersionInfo.Client.SemVer}
-	table.Append(row)
-	row = []string{"Server", v.VersionInfo.Server.SemVer}
-	table.Append(row)
-
-	table.Render()
-
-	return buf.String()
+// Table - the client and server versions
+func (v *Version) Table(opts printers.Options) *printers.Table {
+	table := &printers.Table{Header: []string{"COMPONENT", "VERSION"}}
+	table.Append("Client", v.VersionInfo.Client.SemVer)
+	table.Append("Server", v.VersionInfo.Server.SemVer)
+	return table
 }
@@ -0,0 +1,27 @@
+package objects
+
+import "time"
+
+// Watch event types
+const (
+	WatchAdded    = "ADDED"
+	WatchModified = "MODIFIED"
+	WatchDeleted  = "DELETED"
+)
+
+// WatchEvent - a row of a watched table that was added, changed or removed
+// since the last poll, Key is the first cell of the row and Row maps the
+// column headers to the cells
+type WatchEvent struct {
+	Time    time.Time              `json:"time" yaml:"time"`
+	Type    string                 `json:"type" yaml:"type"`
+	Key     string                 `json:"key" yaml:"key"`
+	Row     map[string]string      `json:"row" yaml:"row"`
+	Changes map[string]WatchChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// WatchChange - the old and new value of a cell
+type WatchChange struct {
+	Old string `json:"old" yaml:"old"`
+	New string `json:"new" yaml:"new"`
+}
@@ -0,0 +1,75 @@
+package objects
+
+// Manifest kinds understood by 'apply -f'
+const (
+	ManifestAPIVersion = "splicectl/v1"
+	WorkspaceKind      = "Workspace"
+)
+
+// WorkspaceManifest - the declared state of a workspace, kept in git and
+// reconciled with 'splicectl apply -f'
+type WorkspaceManifest struct {
+	APIVersion string           `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string           `json:"kind" yaml:"kind"`
+	Metadata   ManifestMetadata `json:"metadata" yaml:"metadata"`
+	Spec       WorkspaceSpec    `json:"spec" yaml:"spec"`
+	// Source - the file the manifest was read from
+	Source string `json:"-" yaml:"-"`
+}
+
+// ManifestMetadata - identifies the object a manifest describes
+type ManifestMetadata struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// WorkspaceSpec - the DatabaseRequest used to create the workspace, plus
+// the image tag of each component and whether it should be paused.  The
+// request fields are written inline in the spec.
+type WorkspaceSpec struct {
+	DatabaseRequest `json:",inline" yaml:",inline"`
+	ImageTags       map[string]string `json:"imageTags,omitempty" yaml:"imageTags,omitempty"`
+	Paused          *bool             `json:"paused,omitempty" yaml:"paused,omitempty"`
+}
+
+// Plan actions
+const (
+	PlanCreate      = "create"
+	PlanSetImageTag = "set-image-tag"
+	PlanPause       = "pause"
+	PlanResume      = "resume"
+	PlanSkip        = "skip"
+	PlanUnchanged   = "unchanged"
+)
+
+// PlanAction - one step 'apply -f' takes to reconcile a workspace
+type PlanAction struct {
+	Workspace string `json:"workspace" yaml:"workspace" table:"WORKSPACE"`
+	Action    string `json:"action" yaml:"action" table:"ACTION"`
+	Component string `json:"component,omitempty" yaml:"component,omitempty" table:"COMPONENT"`
+	From      string `json:"from,omitempty" yaml:"from,omitempty" table:"FROM"`
+	To        string `json:"to,omitempty" yaml:"to,omitempty" table:"TO"`
+	Detail    string `json:"detail,omitempty" yaml:"detail,omitempty" table:"DETAIL"`
+	Result    string `json:"result,omitempty" yaml:"result,omitempty" table:"RESULT,omitempty"`
+}
+
+// Changes - whether the action changes something
+func (pa *PlanAction) Changes() bool {
+	return pa.Action != PlanSkip && pa.Action != PlanUnchanged
+}
+
+// WorkspacePlan - what 'apply -f' will do, and once run what it did, the
+// RESULT column is shown once the plan has been run
+type WorkspacePlan struct {
+	Actions []PlanAction `json:"actions" yaml:"actions" table:",rows"`
+}
+
+// HasChanges - whether any action changes something
+func (wp *WorkspacePlan) HasChanges() bool {
+	for _, a := range wp.Actions {
+		if a.Changes() {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/blang/semver/v4"
-	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 )
@@ -20,6 +22,14 @@ var pauseCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl list workspace
 	splicectl pause --database-name <database> --message "<message>"
+	splicectl pause --database-name <database> --wait --timeout 5m
+	splicectl pause --all-active --name-regex '^dev-' --yes
+	splicectl pause --account-id acme --from-file nightly.txt --continue-on-error
+
+	--all-active, --account-id, --name-regex and --from-file pause every
+	workspace they all match instead of one, --max-concurrency at a time.
+	The selected workspaces are shown and confirmed first, workspaces that
+	are not Active are skipped and the results are output as a table.
 
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -32,26 +42,43 @@ var pauseCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("pause")
 
 		message, _ := cmd.Flags().GetString("message")
+		bulk, err := common.BulkFlags(cmd)
+		if err != nil {
+			c.FatalError(err, "Invalid selectors")
+		}
+		if bulk.Selected() {
+			c.RunBulk(config.BulkCommand{
+				Verb: "pause",
+				Skip: func(db objects.CMClusterInfo) string { return skipUnless(db, config.StateActive) },
+				Run: func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error) {
+					return api.Pause(ctx, database, message)
+				},
+				Want: config.StatePaused,
+			}, bulk, common.WaitFlags(cmd))
+			return
+		}
+
 		databaseName := common.DatabaseName(cmd)
 		if len(databaseName) == 0 {
 			databaseName, dberr = PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of workspaces", dberr)
+				c.FatalError(dberr, "Could not get a list of workspaces")
 			}
 		}
 		if isDatabaseActive(databaseName) {
-			out, err := pauseDatabase(databaseName, message)
+			status, raw, err := c.APIClient().PauseRaw(context.TODO(), databaseName, message)
 			if err != nil {
-				logrus.Warn("Pausing workspace failed.")
+				c.FatalError(err, "Pausing workspace failed.")
 			}
 
 			if semverV1, err := semver.ParseRange(">=0.1.7"); err != nil {
 				logrus.Fatal("Failed to parse SemVer")
 			} else {
 				if semverV1(sv) {
-					displayPauseDatabaseV1(out)
+					displayPauseDatabaseV1(raw)
 				}
 			}
+			c.WaitAfterAction(status, databaseName, config.StatePaused, false, common.WaitFlags(cmd))
 		} else {
 			logrus.Warn("The workspace is not listed as Active, not paused")
 		}
@@ -59,52 +86,26 @@ var pauseCmd = &cobra.Command{
 	},
 }
 
-func displayPauseDatabaseV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+// displayPauseDatabaseV1 - the response is printed as the server sent it
+func displayPauseDatabaseV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func isDatabaseActive(db string) bool {
-	dbJSON, err := c.GetDatabaseList()
-	if err != nil {
-		logrus.WithError(err).Fatal("Error retreiving ClusterId list")
-	}
-	var dbList objects.DatabaseList
-
-	marshErr := json.Unmarshal([]byte(dbJSON), &dbList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall workspace list for ClusterId", marshErr)
-	}
-
-	for _, v := range dbList.Clusters {
-		if v.DcosAppId == db {
-			if v.Status == "Active" {
-				return true
-			}
-			return false
-		}
+// skipUnless - why a workspace not in status is skipped by a bulk pause or
+// resume
+func skipUnless(db objects.CMClusterInfo, status string) string {
+	if db.Status == status {
+		return ""
 	}
-	return false
+	return fmt.Sprintf("not %s", status)
 }
 
-func pauseDatabase(db string, msg string) (string, error) {
-	uri := "splicectl/v1/splicedb/splicedatabasepause"
-
-	var resp *resty.Response
-	var resperr error
-
-	reqJSON := fmt.Sprintf("{ \"appId\": \"%s\", \"message\": \"%s\" }", db, msg)
-	resp, resperr = c.
-		RestyWithHeaders().
-		SetBody(reqJSON).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
+func isDatabaseActive(db string) bool {
+	database, err := c.GetDatabase(db)
+	if err != nil {
+		c.FatalError(err, "Error retreiving ClusterId list")
 	}
-
-	return string(resp.Body()[:]), nil
-
+	return database.Status == "Active"
 }
 
 func init() {
@@ -116,4 +117,6 @@ func init() {
 	pauseCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
 	pauseCmd.Flags().StringP("message", "m", "", "Add a message to the workspace log")
+	common.AddWaitFlags(pauseCmd, 10*time.Minute)
+	common.AddBulkFlags(pauseCmd)
 }
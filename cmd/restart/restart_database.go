@@ -1,13 +1,14 @@
 package restart
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"os"
-	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -21,6 +22,13 @@ var restartDatabaseCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl list workspace
 	splicectl restart workspace --database-name splicedb
+	splicectl restart workspace --database-name splicedb --wait --poll-interval 30s
+	splicectl restart workspace --all-active --name-regex '^dev-' --max-concurrency 3
+
+	--all-active, --all-paused, --account-id, --name-regex and --from-file
+	restart every workspace they all match instead of one, --max-concurrency
+	at a time.  The selected workspaces are shown and confirmed first and the
+	results are output as a table.
 
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -33,55 +41,48 @@ var restartDatabaseCmd = &cobra.Command{
 		var dberr error
 		_, sv := c.VersionDetail.RequirementMet("restart_database")
 
-		databaseName := common.DatabaseName(cmd)
 		forceRestart, _ := cmd.Flags().GetBool("force")
+		bulk, err := common.BulkFlags(cmd)
+		if err != nil {
+			c.FatalError(err, "Invalid selectors")
+		}
+		if bulk.Selected() {
+			c.RunBulk(config.BulkCommand{
+				Verb: "restart",
+				Run: func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error) {
+					return api.Restart(ctx, database, forceRestart)
+				},
+				Want:       config.StateActive,
+				LeaveFirst: true,
+			}, bulk, common.WaitFlags(cmd))
+			return
+		}
+
+		databaseName := common.DatabaseName(cmd)
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of Databases", dberr)
+				c.FatalError(dberr, "Could not get a list of Databases")
 			}
 		}
-		out, err := restartDatabase(databaseName, forceRestart)
+		out, raw, err := c.APIClient().RestartRaw(context.TODO(), databaseName, forceRestart)
 		if err != nil {
-			logrus.WithError(err).Error("Error restarting database")
+			c.FatalError(err, "Error restarting database")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRestartDatabaseV1(out)
+				displayRestartDatabaseV1(out, raw)
 			}
 		}
+		c.WaitAfterAction(out, databaseName, config.StateActive, true, common.WaitFlags(cmd))
 	},
 }
 
-func displayRestartDatabaseV1(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var asData objects.ActionStatus
-	marshErr := json.Unmarshal([]byte(in), &asData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&asData)
-}
-
-func restartDatabase(dbname string, force bool) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/splicedb/splicedatabaserestart?database-name=%s&force=%t", dbname, force)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error restarting the database")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRestartDatabaseV1(out *objects.ActionStatus, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -93,5 +94,7 @@ func init() {
 	restartDatabaseCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
 	restartDatabaseCmd.Flags().BoolP("force", "f", false, "Force the restart")
+	common.AddWaitFlags(restartDatabaseCmd, 15*time.Minute)
+	common.AddBulkFlags(restartDatabaseCmd)
 
 }
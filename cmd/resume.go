@@ -1,14 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/blang/semver/v4"
-	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 )
@@ -20,6 +22,13 @@ var resumeCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl list workspace
 	splicectl resume --database-name <database> --message "<message>"
+	splicectl resume --database-name <database> --wait --timeout 5m
+	splicectl resume --all-paused --account-id acme --yes
+
+	--all-paused, --account-id, --name-regex and --from-file resume every
+	workspace they all match instead of one, --max-concurrency at a time.
+	The selected workspaces are shown and confirmed first, workspaces that
+	are not Paused are skipped and the results are output as a table.
 	
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -32,26 +41,43 @@ var resumeCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("resume")
 
 		message, _ := cmd.Flags().GetString("message")
+		bulk, err := common.BulkFlags(cmd)
+		if err != nil {
+			c.FatalError(err, "Invalid selectors")
+		}
+		if bulk.Selected() {
+			c.RunBulk(config.BulkCommand{
+				Verb: "resume",
+				Skip: func(db objects.CMClusterInfo) string { return skipUnless(db, config.StatePaused) },
+				Run: func(ctx context.Context, api *client.Client, database string) (*objects.ActionStatus, error) {
+					return api.Resume(ctx, database, message)
+				},
+				Want: config.StateActive,
+			}, bulk, common.WaitFlags(cmd))
+			return
+		}
+
 		databaseName := common.DatabaseName(cmd)
 		if len(databaseName) == 0 {
 			databaseName, dberr = PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of workspaces", dberr)
+				c.FatalError(dberr, "Could not get a list of workspaces")
 			}
 		}
 		if isDatabasePaused(databaseName) {
-			out, err := resumeDatabase(databaseName, message)
+			status, raw, err := c.APIClient().ResumeRaw(context.TODO(), databaseName, message)
 			if err != nil {
-				logrus.Warn("Resuming workspace failed.")
+				c.FatalError(err, "Resuming workspace failed.")
 			}
 
 			if semverV1, err := semver.ParseRange(">=0.1.7"); err != nil {
 				logrus.Fatal("Failed to parse SemVer")
 			} else {
 				if semverV1(sv) {
-					displayResumeDatabaseV1(out)
+					displayResumeDatabaseV1(raw)
 				}
 			}
+			c.WaitAfterAction(status, databaseName, config.StateActive, false, common.WaitFlags(cmd))
 		} else {
 			logrus.Warn("The workspace is not listed as Paused, not resuming")
 		}
@@ -59,52 +85,17 @@ var resumeCmd = &cobra.Command{
 	},
 }
 
-func displayResumeDatabaseV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+// displayResumeDatabaseV1 - the response is printed as the server sent it
+func displayResumeDatabaseV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
 func isDatabasePaused(db string) bool {
-	dbJSON, err := c.GetDatabaseList()
+	database, err := c.GetDatabase(db)
 	if err != nil {
-		logrus.WithError(err).Fatal("Error retreiving ClusterId list")
+		c.FatalError(err, "Error retreiving ClusterId list")
 	}
-	var dbList objects.DatabaseList
-
-	marshErr := json.Unmarshal([]byte(dbJSON), &dbList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall workspace list for ClusterId", marshErr)
-	}
-
-	for _, v := range dbList.Clusters {
-		if v.DcosAppId == db {
-			if v.Status == "Paused" {
-				return true
-			}
-			return false
-		}
-	}
-	return false
-}
-
-func resumeDatabase(db string, msg string) (string, error) {
-	uri := "splicectl/v1/splicedb/splicedatabaseresume"
-
-	var resp *resty.Response
-	var resperr error
-
-	reqJSON := fmt.Sprintf("{ \"appId\": \"%s\", \"message\": \"%s\" }", db, msg)
-	resp, resperr = c.
-		RestyWithHeaders().
-		SetBody(reqJSON).
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+	return database.Status == "Paused"
 }
 
 func init() {
@@ -116,4 +107,6 @@ func init() {
 	resumeCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
 
 	resumeCmd.Flags().StringP("message", "m", "", "Add a message to the workspace log")
+	common.AddWaitFlags(resumeCmd, 10*time.Minute)
+	common.AddBulkFlags(resumeCmd)
 }
@@ -1,13 +1,13 @@
 package rollback
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
@@ -30,47 +30,23 @@ var rollbackCMSettingsCmd = &cobra.Command{
 			logrus.Fatal("--component needs to be 'ui' or 'api'")
 		}
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := rollbackCMSettings(component, version)
+		out, raw, err := c.APIClient().RollbackRaw(context.TODO(), client.CMSettings(component), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error rolling back CM Settings")
+			c.FatalError(err, "Error rolling back CM Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRollbackCmSettingsV1(out)
+				displayRollbackCmSettingsV1(out, raw)
 			}
 		}
 	},
 }
 
-func displayRollbackCmSettingsV1(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func rollbackCMSettings(comp string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/rollbackcmsettings?component=%s&version=%d", comp, ver)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error rolling back CM Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRollbackCmSettingsV1(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -1,13 +1,13 @@
 package rollback
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
@@ -37,20 +37,20 @@ var rollbackDatabaseCRCmd = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of workspaces", dberr)
+				c.FatalError(dberr, "Could not get a list of workspaces")
 			}
 		}
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := rollbackDatabaseCR(databaseName, version)
+		out, raw, err := c.APIClient().RollbackRaw(context.TODO(), client.DatabaseCR(databaseName), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting workspace CR Info")
+			c.FatalError(err, "Error rolling back workspace CR")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRollbackDatabaseCRV1(out)
+				displayRollbackDatabaseCRV1(raw)
 			}
 		}
 
@@ -58,43 +58,18 @@ var rollbackDatabaseCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayRollbackDatabaseCRV2(out)
+				displayRollbackDatabaseCRV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayRollbackDatabaseCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayRollbackDatabaseCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func displayRollbackDatabaseCRV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func rollbackDatabaseCR(dbname string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/rollbackdatabasecr?version=%d&database-name=%s", ver, dbname)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting workspace CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRollbackDatabaseCRV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -1,15 +1,15 @@
 package rollback
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
 
 	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 )
 
@@ -24,16 +24,16 @@ var rollbackDefaultCRCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("rollback_default-cr")
 
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := rollbackDefaultCR(version)
+		out, raw, err := c.APIClient().RollbackRaw(context.TODO(), client.DefaultCR(), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error rolling back Default CR")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRollbackDefaultCRV1(out)
+				displayRollbackDefaultCRV1(raw)
 			}
 		}
 
@@ -41,43 +41,18 @@ var rollbackDefaultCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayRollbackDefaultCRV2(out)
+				displayRollbackDefaultCRV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayRollbackDefaultCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayRollbackDefaultCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func displayRollbackDefaultCRV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func rollbackDefaultCR(ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/rollbackdefaultcr?version=%d", ver)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRollbackDefaultCRV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -1,13 +1,13 @@
 package rollback
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
@@ -24,16 +24,16 @@ var rollbackSystemSettingsCmd = &cobra.Command{
 		_, sv := c.VersionDetail.RequirementMet("rollback_system-settings")
 
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := rollbackSystemSettings(version)
+		out, raw, err := c.APIClient().RollbackRaw(context.TODO(), client.SystemSettings(), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error rolling back System Settings")
+			c.FatalError(err, "Error rolling back System Settings")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRollbackSystemSettingsV1(out)
+				displayRollbackSystemSettingsV1(raw)
 			}
 		}
 
@@ -41,43 +41,18 @@ var rollbackSystemSettingsCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayRollbackSystemSettingsV2(out)
+				displayRollbackSystemSettingsV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayRollbackSystemSettingsV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayRollbackSystemSettingsV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func displayRollbackSystemSettingsV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func rollbackSystemSettings(ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/rollbacksystemsettings?version=%d", ver)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error rolling back System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRollbackSystemSettingsV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -1,13 +1,14 @@
 package rollback
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
 	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
@@ -28,16 +29,16 @@ var rollbackVaultKeyCmd = &cobra.Command{
 			keyPath = strings.TrimPrefix(keyPath, "secrets/")
 		}
 		version, _ := cmd.Flags().GetInt("version")
-		out, err := rollbackVaultKeyData(keyPath, version)
+		out, raw, err := c.APIClient().RollbackRaw(context.TODO(), client.VaultKey(keyPath), version)
 		if err != nil {
-			logrus.WithError(err).Error("Error rolling back Vault Key")
+			c.FatalError(err, "Error rolling back Vault Key")
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayRollbackVaultKeyV1(out)
+				displayRollbackVaultKeyV1(raw)
 			}
 		}
 
@@ -45,43 +46,18 @@ var rollbackVaultKeyCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayRollbackVaultKeyV2(out)
+				displayRollbackVaultKeyV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayRollbackVaultKeyV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
+func displayRollbackVaultKeyV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func displayRollbackVaultKeyV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	var vvData objects.VaultVersion
-	marshErr := json.Unmarshal([]byte(in), &vvData)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
-
-	c.OutputData(&vvData)
-}
-
-func rollbackVaultKeyData(keypath string, ver int) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/rollbackvaultkey?version=%d&keypath=%s", ver, keypath)
-	resp, resperr := c.RestyWithHeaders().
-		Post(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error rolling back Vault Key")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayRollbackVaultKeyV2(out *objects.VaultVersion, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/get"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Collect the diagnostics of a workspace into a tar.gz for support",
+	Long: `EXAMPLES
+	splicectl support-bundle --database-name splicedb
+	splicectl support-bundle -d splicedb --file splicedb-bundle.tar.gz
+	splicectl support-bundle -d splicedb --since 2h --tail -1
+
+	The bundle holds, under a directory named after it:
+	  manifest.yaml          what was collected, from where, and what failed
+	  version.yaml           the client and API server versions
+	  database-cr.yaml       get database-cr
+	  database-status.yaml   get database-status
+	  image-tags.yaml        get image-tag of every component
+	  kubernetes/            the pods, statefulsets, persistent volume claims,
+	                         events and ingresses of the workspace namespace
+	  logs/                  the last --tail lines of the pod logs of the last
+	                         --since, as get logs saves them
+
+	Values of keys that look like passwords, tokens, secrets or keys are
+	replaced with REDACTED, and Kubernetes secrets are never collected.  In
+	the logs the values of settings named like them, as name=value or
+	name: value, and bearer tokens are replaced line by line; other
+	sensitive data a log prints is kept, check the logs before sharing the
+	bundle.  Anything that can't be collected is listed with its error and
+	the rest of the bundle is still written.  The default file name is
+	support-bundle-<workspace>-<time>.tar.gz.
+
+	Note: --database-name and -d are the preferred way to supply the database name.
+	However, --database and --workspace can also be used as well. In the event that
+	more than one of them is supplied database-name and d are preferred over all
+	and workspace is preferred over database. The most preferred option that is
+	supplied will be used and a message will be displayed letting you know which
+	option was chosen if more than one were supplied.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var dberr error
+		c.VersionDetail.RequirementMet("support-bundle")
+
+		databaseName := common.DatabaseName(cmd)
+		if len(databaseName) == 0 {
+			databaseName, dberr = PromptForDatabaseName()
+			if dberr != nil {
+				c.FatalError(dberr, "Could not get a list of workspaces")
+			}
+		}
+		fileName, _ := cmd.Flags().GetString("file")
+		overwrite, _ := cmd.Flags().GetBool("overwrite")
+		concurrency, _ := cmd.Flags().GetInt("max-concurrency")
+		if concurrency < 1 {
+			logrus.Fatalf("--max-concurrency must be at least 1, got %d", concurrency)
+		}
+		since, _ := cmd.Flags().GetString("since")
+		tail, _ := cmd.Flags().GetInt64("tail")
+
+		database, err := c.GetDatabase(databaseName)
+		if err != nil {
+			c.FatalError(err, "Could not find the workspace")
+		}
+		now := time.Now()
+		window := time.Duration(0)
+		if len(since) > 0 {
+			start, err := common.ParseSince(since, now)
+			if err != nil {
+				c.FatalError(fmt.Errorf("invalid --since: %w", err), "Could not collect the logs")
+			}
+			if window = now.Sub(start); window <= 0 {
+				c.FatalError(fmt.Errorf("--since must be in the past, got '%s'", since), "Could not collect the logs")
+			}
+		}
+		if len(fileName) == 0 {
+			fileName = fmt.Sprintf("support-bundle-%s-%s.tar.gz", databaseName, now.Format("20060102-150405"))
+		}
+		archive, err := common.CreateArchive(fileName, overwrite)
+		if err != nil {
+			logrus.WithError(err).Fatal("Could not create the support bundle")
+		}
+
+		b := &supportBundle{
+			archive: archive,
+			index: &objects.SupportBundle{
+				Workspace: databaseName,
+				Namespace: database.Namespace,
+				Created:   now.UTC().Format(time.RFC3339),
+				Client:    c.VersionDetail.VersionInfo.Client.SemVer,
+				Server:    c.VersionDetail.VersionInfo.Server.SemVer,
+			},
+		}
+		b.collect(context.TODO(), databaseName, database.Namespace, window, tail, concurrency)
+
+		if err := b.addYAML("manifest.yaml", b.index); err != nil {
+			logrus.WithError(err).Error("Could not add the manifest to the support bundle")
+		}
+		if err := archive.Close(); err != nil {
+			logrus.WithError(err).Fatalf("Could not write the support bundle %s", fileName)
+		}
+
+		c.OutputData(b.index)
+		if failed := b.index.Errors(); failed > 0 {
+			logrus.Warnf("Wrote %s, %d of %d entries could not be collected", fileName, failed, len(b.index.Entries))
+		} else {
+			logrus.Infof("Wrote %s", fileName)
+		}
+		logrus.Warn("Only the settings that look sensitive are redacted in the logs, check them before sharing the bundle")
+	},
+}
+
+// supportBundle - the archive being written and its index
+type supportBundle struct {
+	archive *common.Archive
+	index   *objects.SupportBundle
+}
+
+// collect - adds everything the bundle holds, a part that fails is listed
+// in the index and the others are still collected
+func (b *supportBundle) collect(ctx context.Context, databaseName, namespace string, since time.Duration, tail int64, concurrency int) {
+	api := c.APIClient()
+	b.add("version.yaml", "version", func() (interface{}, error) {
+		return &c.VersionDetail, nil
+	})
+	b.add("database-cr.yaml", "get database-cr", func() (interface{}, error) {
+		return api.GetDatabaseCR(ctx, databaseName, 0)
+	})
+	b.add("database-status.yaml", "get database-status", func() (interface{}, error) {
+		return api.GetDatabaseStatus(ctx, databaseName)
+	})
+	b.add("image-tags.yaml", "get image-tag", func() (interface{}, error) {
+		tags := &objects.ImageTagList{}
+		var failed error
+		for _, component := range objects.ImageTagComponents {
+			list, err := api.GetImageTags(ctx, databaseName, component)
+			if err != nil {
+				failed = fmt.Errorf("%s: %w", component, err)
+				continue
+			}
+			tags.ImageTags = append(tags.ImageTags, list.ImageTags...)
+		}
+		if len(tags.ImageTags) == 0 && failed != nil {
+			return nil, failed
+		}
+		return tags, nil
+	})
+
+	kube, err := common.KubeClient()
+	if err == nil && kube == nil {
+		err = errors.New("no kubernetes config was found")
+	}
+	if err != nil {
+		b.index.Entries = append(b.index.Entries, objects.SupportBundleEntry{Name: "kubernetes/", Source: "kubernetes", Error: err.Error()})
+		return
+	}
+	b.collectKubernetes(ctx, kube, namespace)
+	b.collectLogs(ctx, kube, namespace, since, tail, concurrency)
+}
+
+// collectKubernetes - the manifests and events of the workspace namespace,
+// the ingresses of the core namespace are included as get urls uses them
+func (b *supportBundle) collectKubernetes(ctx context.Context, kube kubernetes.Interface, namespace string) {
+	opts := v1.ListOptions{}
+	b.add("kubernetes/pods.yaml", "kubernetes pods", func() (interface{}, error) {
+		return kube.CoreV1().Pods(namespace).List(ctx, opts)
+	})
+	b.add("kubernetes/statefulsets.yaml", "kubernetes statefulsets", func() (interface{}, error) {
+		return kube.AppsV1().StatefulSets(namespace).List(ctx, opts)
+	})
+	b.add("kubernetes/persistentvolumeclaims.yaml", "kubernetes persistentvolumeclaims", func() (interface{}, error) {
+		return kube.CoreV1().PersistentVolumeClaims(namespace).List(ctx, opts)
+	})
+	b.add("kubernetes/events.yaml", "kubernetes events", func() (interface{}, error) {
+		return kube.CoreV1().Events(namespace).List(ctx, opts)
+	})
+	b.add("kubernetes/ingresses.yaml", "kubernetes ingresses", func() (interface{}, error) {
+		ingresses := map[string]interface{}{}
+		for _, ns := range []string{common.Target.Namespace, namespace} {
+			list, err := kube.NetworkingV1().Ingresses(ns).List(ctx, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", ns, err)
+			}
+			ingresses[ns] = list
+		}
+		return ingresses, nil
+	})
+}
+
+// collectLogs - the redacted logs of every pod of the namespace, one index
+// entry per pod
+func (b *supportBundle) collectLogs(ctx context.Context, kube kubernetes.Interface, namespace string, since time.Duration, tail int64, concurrency int) {
+	pi := kube.CoreV1().Pods(namespace)
+	pods, err := pi.List(ctx, v1.ListOptions{})
+	if err != nil {
+		b.index.Entries = append(b.index.Entries, objects.SupportBundleEntry{Name: "logs/", Source: "get logs", Error: err.Error()})
+		return
+	}
+	summary := get.SaveLogs(pods.Items, redactedSink{sink: b.archive, prefix: "logs"}, pi, since, tail, concurrency)
+	for idx, pod := range summary.Pods {
+		name := path.Join("logs", pod.Pod)
+		if len(pods.Items[idx].Spec.Containers) == 1 {
+			name += ".log"
+		}
+		b.index.Entries = append(b.index.Entries, objects.SupportBundleEntry{Name: name, Source: "get logs", Bytes: pod.Bytes, Error: pod.Error})
+	}
+}
+
+// add - adds what fetch returns, redacted, as YAML under name
+func (b *supportBundle) add(name, source string, fetch func() (interface{}, error)) {
+	entry := objects.SupportBundleEntry{Name: name, Source: source}
+	v, err := fetch()
+	if err == nil {
+		entry.Bytes, err = b.addRedacted(name, v)
+	}
+	if err != nil {
+		logrus.WithError(err).Warnf("Could not collect %s", name)
+		entry.Error = err.Error()
+	}
+	b.index.Entries = append(b.index.Entries, entry)
+}
+
+func (b *supportBundle) addRedacted(name string, v interface{}) (int64, error) {
+	redacted, err := common.Redact(v)
+	if err != nil {
+		return 0, err
+	}
+	data, err := yaml.Marshal(redacted)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(data)), b.archive.Add(name, data)
+}
+
+func (b *supportBundle) addYAML(name string, v interface{}) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.archive.Add(name, data)
+}
+
+// redactedSink - writes the entries of a sink below a directory, each line
+// redacted with common.RedactLine
+type redactedSink struct {
+	sink   get.LogSink
+	prefix string
+}
+
+func (rs redactedSink) Create(name string) (io.WriteCloser, error) {
+	w, err := rs.sink.Create(path.Join(rs.prefix, name))
+	if err != nil {
+		return nil, err
+	}
+	return common.NewRedactWriter(w), nil
+}
+
+func init() {
+	RootCmd.AddCommand(supportBundleCmd)
+
+	// add database name and aliases
+	supportBundleCmd.Flags().StringP("database-name", "d", "", "Specify the database name")
+	supportBundleCmd.Flags().String("database", "", "Alias for database-name, prefer the use of -d and --database-name.")
+	supportBundleCmd.Flags().String("workspace", "", "Alias for database-name, prefer the use of -d and --database-name.")
+
+	supportBundleCmd.Flags().StringP("file", "f", "", "Name of the .tar.gz to write, default support-bundle-<workspace>-<time>.tar.gz")
+	supportBundleCmd.Flags().Bool("overwrite", false, "Replace the file when it exists")
+	supportBundleCmd.Flags().Int("max-concurrency", 5, "How many pods the logs are downloaded of at once")
+	supportBundleCmd.Flags().String("since", "24h", "Only log lines newer than a duration, ie: 30m, 2h, 7d, empty for the whole log")
+	supportBundleCmd.Flags().Int64("tail", 10000, "Only the last lines of each container log, -1 for all")
+	supportBundleCmd.MarkFlagFilename("file", "tar.gz", "tgz")
+}
@@ -1,70 +1,96 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/sirupsen/logrus"
-	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/common"
 )
 
-type (
-	cspAnswer struct {
-		CSP string `survey:"cspselect"`
-	}
-	accountIDAnswer struct {
-		AccountID string `survey:"accountid"`
+var cspList = []string{
+	"NONE",
+	"OP",
+	"AWS",
+	"AZ",
+	"GCP",
+}
+
+// promptOptions - prompts are written to stderr so the output can be piped,
+// the options of a select are filtered fuzzily as you type
+func promptOptions() []survey.AskOpt {
+	return []survey.AskOpt{
+		survey.WithStdio(os.Stdin, os.Stderr, os.Stderr),
+		survey.WithFilter(func(filter string, value string, index int) bool {
+			return common.FuzzyMatch(filter, value)
+		}),
 	}
-	databaseAnswer struct {
-		DatabaseName string `survey:"databasename"` // or you can tag fields to match a specific name
+}
+
+// promptKey - the key the answer to a prompt is remembered by, answers in
+// one context are not offered in another
+func promptKey(name string) string {
+	if c.Context != nil {
+		return c.Context.Name + "/" + name
 	}
-)
+	return "default/" + name
+}
 
-var (
-	cspList = []string{
-		"NONE",
-		"OP",
-		"AWS",
-		"AZ",
-		"GCP",
-	}
-	cspSurvey = []*survey.Question{
-		{
-			Name: "cspselect",
-			Prompt: &survey.Select{
-				Message: "Choose a Cloud Service Provider:",
-				Options: cspList,
-			},
-		},
-	}
-	cspAnswers       = &cspAnswer{}
-	accountIDAnswers = &accountIDAnswer{}
-	databaseAnswers  = &databaseAnswer{}
-)
+// selectOne - asks for one of options, the answer given the last time in
+// this context is the default and the new answer is remembered
+func selectOne(name, message string, options []string) (string, error) {
+	prompt := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: 15,
+	}
+	key := promptKey(name)
+	path, perr := common.PromptDefaultsPath()
+	if perr == nil {
+		last := common.ReadPromptDefaults(path)[key]
+		for _, option := range options {
+			if option == last {
+				prompt.Default = last
+			}
+		}
+	}
+
+	answer := ""
+	if err := survey.AskOne(prompt, &answer, promptOptions()...); err != nil {
+		return "", err
+	}
+	if perr == nil {
+		if err := common.SavePromptDefault(path, key, answer); err != nil {
+			logrus.WithError(err).Debug("Could not remember the answer")
+		}
+	}
+	return answer, nil
+}
 
 // PromptForCSP - prompt the user on the command line for cloud service provider
 func PromptForCSP() (string, error) {
-	opts := survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)
-	if err := survey.Ask(cspSurvey, cspAnswers, opts); err != nil {
-		return "", err
+	if c.NonInteractive {
+		return "", &config.MissingInputError{Inputs: []string{"--cloud-provider"}}
 	}
-	return cspAnswers.CSP, nil
+	return selectOne("cloud-provider", "Choose a Cloud Service Provider:", cspList)
 }
 
 // PromptForAccountID - prompt the user on the command line for account id
 func PromptForAccountID() (string, error) {
-	out, err := c.GetAccounts()
+	if c.NonInteractive {
+		return "", &config.MissingInputError{Inputs: []string{"--account-id"}}
+	}
+	accounts, err := c.APIClient().ListAccounts(context.TODO())
 	if err != nil {
-		logrus.WithError(err).Error("Error getting Default CR Info")
+		logrus.WithError(err).Error("Error getting Accounts")
 		return "", err
 	}
-
-	var accounts objects.AccountList
-	if err := json.Unmarshal([]byte(out), &accounts); err != nil {
-		logrus.Fatal("Could not unmarshall data", err)
+	if len(accounts.Accounts) == 0 {
+		return "", fmt.Errorf("no accounts to choose from: %w", config.ErrNotFound)
 	}
 
 	acctArray := make([]string, 0)
@@ -73,67 +99,55 @@ func PromptForAccountID() (string, error) {
 		acctArray = append(acctArray, accountID)
 	}
 
-	accountIDSurvey := []*survey.Question{
-		{
-			Name: "accountid",
-			Prompt: &survey.Select{
-				Message: "Choose an account:",
-				Options: acctArray,
-			},
-		},
-	}
-
-	opts := survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)
-
-	// perform the questions
-	if err = survey.Ask(accountIDSurvey, accountIDAnswers, opts); err != nil {
+	answer, err := selectOne("account-id", "Choose an account:", acctArray)
+	if err != nil {
 		return "", err
 	}
-
-	acctID := strings.TrimSpace(strings.Split(accountIDAnswers.AccountID, "(")[0])
+	acctID := strings.TrimSpace(strings.Split(answer, "(")[0])
 	return acctID, nil
 }
 
 // PromptForDatabaseName - prompt the user on the command line for name
 func PromptForDatabaseName() (string, error) {
-	out, err := c.GetDatabaseList()
+	if c.NonInteractive {
+		return "", &config.MissingInputError{Inputs: []string{"--database-name/-d"}}
+	}
+	dbList, err := c.GetDatabaseListStruct()
 	if err != nil {
 		logrus.WithError(err).Error("Error getting Database List")
 		return "", err
 	}
-	var dbList objects.DatabaseList
-
-	marshErr := json.Unmarshal([]byte(out), &dbList)
-	if marshErr != nil {
-		logrus.Fatal("Could not unmarshall data", marshErr)
-	}
 	var dbArray []string
 	for _, v := range dbList.Clusters {
 		dbArray = append(dbArray, v.DcosAppId)
 	}
-
-	// the questions to ask
-	var databaseSurvey = []*survey.Question{
-		{
-			Name: "databasename",
-			Prompt: &survey.Select{
-				Message: "Choose a database:",
-				Options: dbArray,
-			},
-		},
+	if len(dbArray) == 0 {
+		return "", fmt.Errorf("no workspaces to choose from: %w", config.ErrNotFound)
 	}
 
-	opts := survey.WithStdio(os.Stdin, os.Stderr, os.Stderr)
+	return selectOne("database-name", "Choose a database:", dbArray)
+}
+
+// PromptForConfirm - ask the user a yes/no question on the command line
+func PromptForConfirm(message string, defaultAnswer bool) (bool, error) {
+	if c.NonInteractive {
+		return false, &config.MissingInputError{Inputs: []string{fmt.Sprintf("--yes to answer '%s'", message)}}
+	}
+	answer := defaultAnswer
+	prompt := &survey.Confirm{
+		Message: message,
+		Default: defaultAnswer,
+	}
 
-	// perform the questions
-	if err = survey.Ask(databaseSurvey, databaseAnswers, opts); err != nil {
-		logrus.Fatal("No databases on the list")
+	if err := survey.AskOne(prompt, &answer, promptOptions()...); err != nil {
+		return false, err
 	}
-	return databaseAnswers.DatabaseName, nil
+	return answer, nil
 }
 
 func addTUIFunctionsToConfig() {
 	c.PromptForCSP = PromptForCSP
 	c.PromptForAccountID = PromptForAccountID
 	c.PromptForDatabaseName = PromptForDatabaseName
+	c.PromptForConfirm = PromptForConfirm
 }
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/printers"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// requestTimeout - how long a load may take before it is reported as failed
+const requestTimeout = 30 * time.Second
+
+// ansiEscape - the color codes of a log line, the dashboard draws its own
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// dashboard - loads what the model shows, every load runs on its own
+// goroutine and hands its result to the event loop as an update, so only
+// the event loop touches the model
+type dashboard struct {
+	m        *model
+	api      *client.Client
+	updates  chan func(*model)
+	selector string
+	tail     int64
+	// actions - the running action, it is waited for on the way out so its
+	// audit record is finished before the command is
+	actions sync.WaitGroup
+}
+
+// refresh - reloads the workspaces and the detail of the selected one
+func (d *dashboard) refresh() {
+	d.loadList()
+	if db := d.m.current(); db != nil {
+		d.loadDetail(db.DcosAppId)
+	}
+}
+
+func (d *dashboard) loadList() {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		list, err := d.api.ListDatabases(ctx)
+		d.updates <- func(m *model) {
+			empty := m.current() == nil
+			m.setDatabases(list, err, time.Now())
+			if db := m.current(); empty && db != nil {
+				d.loadDetail(db.DcosAppId)
+			}
+		}
+	}()
+}
+
+// loadDetail - the status, image tags and CR conditions of a workspace
+func (d *dashboard) loadDetail(name string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		det := &detail{loaded: time.Now()}
+		det.status, det.statusErr = d.api.GetDatabaseStatus(ctx, name)
+		for _, component := range objects.ImageTagComponents {
+			tags, err := d.api.GetImageTags(ctx, name, component)
+			if err != nil {
+				det.tagsErr = fmt.Errorf("%s: %w", component, err)
+				continue
+			}
+			det.tags = append(det.tags, tags.ImageTags...)
+		}
+		cr, err := d.api.GetDatabaseCR(ctx, name, 0)
+		if err == nil {
+			var crList *objects.DatabaseCRList
+			if crList, err = cr.List(); err == nil {
+				det.components = crList.Components()
+			}
+		}
+		det.crErr = err
+		d.updates <- func(m *model) {
+			m.details[name] = det
+		}
+	}()
+}
+
+// loadVersions - the versions of the CR of a workspace
+func (d *dashboard) loadVersions(name string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		versions, err := d.api.ListVersions(ctx, client.DatabaseCR(name))
+		d.updates <- func(m *model) {
+			m.setVersions(versions, err)
+		}
+	}()
+}
+
+// loadVersionCR - one version of the CR of a workspace, as YAML
+func (d *dashboard) loadVersionCR(name string, version int) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		var lines []string
+		cr, err := d.api.GetDatabaseCR(ctx, name, version)
+		if err == nil {
+			lines = strings.Split(strings.TrimRight(printers.ToYAML(cr), "\n"), "\n")
+		}
+		d.updates <- func(m *model) {
+			m.setLines(paneVersionCR, lines, err)
+		}
+	}()
+}
+
+// loadLogs - the last lines of every container of the pods of a workspace
+// that match the selector, each line starts with pod/container
+func (d *dashboard) loadLogs(namespace string) {
+	go func() {
+		lines, err := d.logs(namespace)
+		d.updates <- func(m *model) {
+			m.setLines(paneLogs, lines, err)
+		}
+	}()
+}
+
+func (d *dashboard) logs(namespace string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	kube, err := common.KubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("could not get the kube config: %w", err)
+	}
+	pi := kube.CoreV1().Pods(namespace)
+	pods, err := pi.List(ctx, v1.ListOptions{LabelSelector: d.selector})
+	if err != nil {
+		return nil, fmt.Errorf("could not list the pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pods in %s match '%s'", namespace, d.selector)
+	}
+
+	lines := make([]string, 0)
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			prefix := fmt.Sprintf("%s/%s ", pod.Name, container.Name)
+			opts := &core.PodLogOptions{Container: container.Name, TailLines: &d.tail}
+			raw, err := pi.GetLogs(pod.Name, opts).DoRaw(ctx)
+			if err != nil {
+				lines = append(lines, prefix+"could not get the log: "+err.Error())
+				continue
+			}
+			for _, text := range strings.Split(strings.TrimRight(string(raw), "\n"), "\n") {
+				lines = append(lines, prefix+ansiEscape.ReplaceAllString(text, ""))
+			}
+		}
+	}
+	return lines, nil
+}
+
+// runAction - pauses, resumes or restarts a workspace, it is recorded in
+// the audit log like the command of the same name
+func (d *dashboard) runAction(a action, name string) {
+	c.StartAudit("ui "+string(a), []string{"--database-name", name}, name, c.Environment)
+	api := c.APIClient()
+	d.actions.Add(1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		var (
+			status *objects.ActionStatus
+			err    error
+		)
+		switch a {
+		case actionPause:
+			status, err = api.Pause(ctx, name, "")
+		case actionResume:
+			status, err = api.Resume(ctx, name, "")
+		case actionRestart:
+			status, err = api.Restart(ctx, name, false)
+		}
+		if err == nil && status != nil && !status.Success && len(status.Error) > 0 {
+			err = errors.New(status.Error)
+		}
+		exitStatus := 0
+		if err != nil {
+			exitStatus = config.ExitCode(err)
+		}
+		c.FinishAudit(exitStatus, err)
+		d.actions.Done()
+		d.updates <- func(m *model) {
+			m.finished(err)
+			d.refresh()
+		}
+	}()
+}
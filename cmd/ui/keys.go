@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// key - a key press, printable keys are the character itself
+type key string
+
+const (
+	keyUp       key = "up"
+	keyDown     key = "down"
+	keyPageUp   key = "pgup"
+	keyPageDown key = "pgdown"
+	keyHome     key = "home"
+	keyEnd      key = "end"
+	keyEnter    key = "enter"
+	keyEsc      key = "esc"
+	keyCtrlC    key = "ctrl+c"
+)
+
+// escapeKeys - the escape sequences terminals send for the keys the
+// dashboard uses, in both the normal and the application cursor modes
+var escapeKeys = map[string]key{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+	"\x1b[H":  keyHome,
+	"\x1b[F":  keyEnd,
+	"\x1bOH":  keyHome,
+	"\x1bOF":  keyEnd,
+	"\x1b[1~": keyHome,
+	"\x1b[4~": keyEnd,
+}
+
+// parseKeys - the keys in one read from a raw terminal, unknown escape
+// sequences are dropped
+func parseKeys(b []byte) []key {
+	keys := make([]key, 0, len(b))
+	for len(b) > 0 {
+		switch b[0] {
+		case 0x1b:
+			if len(b) == 1 {
+				return append(keys, keyEsc)
+			}
+			n := escapeLength(b)
+			if k, ok := escapeKeys[string(b[:n])]; ok {
+				keys = append(keys, k)
+			}
+			b = b[n:]
+			continue
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x03:
+			keys = append(keys, keyCtrlC)
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r != utf8.RuneError && r >= ' ' {
+				keys = append(keys, key(string(r)))
+			}
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// escapeLength - the length of the escape sequence b starts with, a CSI
+// sequence ends with a byte in 0x40-0x7e
+func escapeLength(b []byte) int {
+	if len(b) < 3 || (b[1] != '[' && b[1] != 'O') {
+		return 2
+	}
+	if b[1] == 'O' {
+		return 3
+	}
+	for i := 2; i < len(b); i++ {
+		if b[i] >= 0x40 && b[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(b)
+}
+
+// readKeys - sends the keys read from r until it fails
+func readKeys(r io.Reader, keys chan<- key) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		for _, k := range parseKeys(buf[:n]) {
+			keys <- k
+		}
+		if err != nil {
+			return
+		}
+	}
+}
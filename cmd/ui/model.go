@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+// pane - what the right hand side of the dashboard shows
+type pane int
+
+const (
+	paneDetail pane = iota
+	paneVersions
+	paneVersionCR
+	paneLogs
+)
+
+// action - a change to the selected workspace, confirmed before it runs
+type action string
+
+const (
+	actionPause   action = "pause"
+	actionResume  action = "resume"
+	actionRestart action = "restart"
+)
+
+// effect - the work the dashboard does after a key press, the model only
+// holds state so it can be tested without a terminal or an API server
+type effect int
+
+const (
+	effectNone effect = iota
+	effectQuit
+	effectRefresh
+	effectLoadDetail
+	effectLoadVersions
+	effectLoadVersionCR
+	effectLoadLogs
+	effectRunAction
+)
+
+// pageSize - the lines page up and page down scroll
+const pageSize = 10
+
+// detail - what the dashboard knows about one workspace, each part is
+// loaded on its own so one failure does not hide the rest
+type detail struct {
+	loaded     time.Time
+	status     objects.DatabaseStatus
+	statusErr  error
+	tags       []objects.ImageTag
+	tagsErr    error
+	components []objects.ComponentCondition
+	crErr      error
+}
+
+// model - the state of the dashboard
+type model struct {
+	databases []objects.CMClusterInfo
+	listErr   error
+	refreshed time.Time
+	selected  int
+	details   map[string]*detail
+
+	pane     pane
+	scroll   int
+	versions *objects.VaultVersionList
+	version  int
+	lines    []string
+	loading  bool
+	loadErr  error
+
+	confirm   action
+	confirmOn string
+	running   action
+	runningOn string
+	message   string
+}
+
+func newModel() *model {
+	return &model{details: map[string]*detail{}}
+}
+
+// current - the selected workspace, nil while the list is empty
+func (m *model) current() *objects.CMClusterInfo {
+	if m.selected < 0 || m.selected >= len(m.databases) {
+		return nil
+	}
+	return &m.databases[m.selected]
+}
+
+// setDatabases - replaces the list, keeping the selected workspace when it
+// is still there
+func (m *model) setDatabases(list *objects.DatabaseList, err error, now time.Time) {
+	m.listErr = err
+	if err != nil {
+		return
+	}
+	name := ""
+	if db := m.current(); db != nil {
+		name = db.DcosAppId
+	}
+	m.databases = list.Clusters
+	m.refreshed = now
+	m.selected = 0
+	for i, db := range m.databases {
+		if db.DcosAppId == name {
+			m.selected = i
+		}
+	}
+}
+
+// showPane - switches the right hand side to p, which is loading
+func (m *model) showPane(p pane) {
+	m.pane = p
+	m.scroll = 0
+	m.lines = nil
+	m.loadErr = nil
+	m.loading = true
+}
+
+// setLines - the text of the versions, CR or logs pane has loaded
+func (m *model) setLines(p pane, lines []string, err error) {
+	if m.pane != p {
+		return
+	}
+	m.loading = false
+	m.lines = lines
+	m.loadErr = err
+	if p == paneLogs {
+		// logs are read from the bottom
+		m.scroll = len(lines)
+	}
+}
+
+// setVersions - the CR versions of the selected workspace have loaded, the
+// newest is selected
+func (m *model) setVersions(versions *objects.VaultVersionList, err error) {
+	if m.pane != paneVersions {
+		return
+	}
+	m.loading = false
+	m.versions = versions
+	m.loadErr = err
+	m.version = 0
+	if versions != nil && len(versions.Versions) > 0 {
+		m.version = len(versions.Versions) - 1
+	}
+}
+
+// selectedVersion - the CR version under the cursor of the versions pane
+func (m *model) selectedVersion() (objects.VaultVersion, bool) {
+	if m.versions == nil || m.version < 0 || m.version >= len(m.versions.Versions) {
+		return objects.VaultVersion{}, false
+	}
+	return m.versions.Versions[m.version], true
+}
+
+// handleKey - updates the model for a key press and says what to do next
+func (m *model) handleKey(k key) effect {
+	if len(m.confirm) > 0 {
+		pending, name := m.confirm, m.confirmOn
+		m.confirm = ""
+		m.confirmOn = ""
+		if k == "y" || k == "Y" {
+			m.running = pending
+			m.runningOn = name
+			m.message = ""
+			return effectRunAction
+		}
+		m.message = fmt.Sprintf("The %s of %s was cancelled", pending, name)
+		return effectNone
+	}
+	m.message = ""
+
+	switch k {
+	case "q", keyCtrlC:
+		return effectQuit
+	case keyEsc:
+		switch m.pane {
+		case paneVersionCR:
+			m.pane = paneVersions
+		case paneVersions, paneLogs:
+			m.pane = paneDetail
+		}
+		m.scroll = 0
+		return effectNone
+	case "g":
+		return effectRefresh
+	case keyPageUp:
+		m.scrollBy(-pageSize)
+		return effectNone
+	case keyPageDown:
+		m.scrollBy(pageSize)
+		return effectNone
+	case keyHome:
+		m.scroll = 0
+		return effectNone
+	case keyEnd:
+		m.scroll = len(m.lines)
+		return effectNone
+	}
+
+	if m.current() == nil {
+		return effectNone
+	}
+	switch m.pane {
+	case paneVersions:
+		return m.versionsKey(k)
+	case paneVersionCR, paneLogs:
+		switch k {
+		case keyUp, "k":
+			m.scrollBy(-1)
+		case keyDown, "j":
+			m.scrollBy(1)
+		}
+		return effectNone
+	}
+
+	switch k {
+	case keyUp, "k":
+		return m.move(-1)
+	case keyDown, "j":
+		return m.move(1)
+	case keyEnter:
+		return effectLoadDetail
+	case "v":
+		m.showPane(paneVersions)
+		m.versions = nil
+		return effectLoadVersions
+	case "l":
+		m.showPane(paneLogs)
+		return effectLoadLogs
+	case "p":
+		return m.ask(actionPause, "Active")
+	case "r":
+		return m.ask(actionResume, "Paused")
+	case "R":
+		return m.ask(actionRestart, "")
+	}
+	return effectNone
+}
+
+// versionsKey - the versions pane moves between versions and opens one
+func (m *model) versionsKey(k key) effect {
+	switch k {
+	case keyUp, "k":
+		if m.version > 0 {
+			m.version--
+		}
+	case keyDown, "j":
+		if m.versions != nil && m.version < len(m.versions.Versions)-1 {
+			m.version++
+		}
+	case keyEnter:
+		if _, ok := m.selectedVersion(); ok {
+			m.showPane(paneVersionCR)
+			return effectLoadVersionCR
+		}
+	}
+	return effectNone
+}
+
+// move - selects another workspace, its detail is loaded unless it is
+// already known
+func (m *model) move(delta int) effect {
+	next := m.selected + delta
+	if next < 0 || next >= len(m.databases) {
+		return effectNone
+	}
+	m.selected = next
+	m.scroll = 0
+	if _, ok := m.details[m.databases[next].DcosAppId]; ok {
+		return effectNone
+	}
+	return effectLoadDetail
+}
+
+// ask - asks for confirmation of an action, when the selected workspace is
+// in the state the action needs.  The workspace is kept with the action as
+// a refresh can change the selection before it is confirmed
+func (m *model) ask(a action, state string) effect {
+	db := m.current()
+	switch {
+	case len(m.running) > 0:
+		m.message = fmt.Sprintf("Wait for the %s of %s to finish", m.running, m.runningOn)
+	case len(state) > 0 && db.Status != state:
+		m.message = fmt.Sprintf("%s is %s, only %s workspaces can %s", db.DcosAppId, db.Status, state, a)
+	default:
+		m.confirm = a
+		m.confirmOn = db.DcosAppId
+	}
+	return effectNone
+}
+
+// finished - the running action is done, err is nil when it succeeded
+func (m *model) finished(err error) {
+	if err != nil {
+		m.message = fmt.Sprintf("The %s of %s failed: %v", m.running, m.runningOn, err)
+	} else {
+		m.message = fmt.Sprintf("The %s of %s was accepted", m.running, m.runningOn)
+	}
+	m.running = ""
+	m.runningOn = ""
+}
+
+func (m *model) scrollBy(delta int) {
+	m.scroll += delta
+	if m.scroll < 0 {
+		m.scroll = 0
+	}
+}
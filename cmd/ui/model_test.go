@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[A\x1bOB\x1b[5~\r\x03\x1b[99Xq\x1b"))
+	want := []key{"j", keyUp, keyDown, keyPageUp, keyEnter, keyCtrlC, "q", keyEsc}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys() = %v, want %v", got, want)
+	}
+}
+
+func testModel() *model {
+	m := newModel()
+	m.setDatabases(&objects.DatabaseList{Clusters: []objects.CMClusterInfo{
+		{DcosAppId: "db1", Status: "Active"},
+		{DcosAppId: "db2", Status: "Paused"},
+	}}, nil, time.Now())
+	return m
+}
+
+func TestHandleKey(t *testing.T) {
+	m := testModel()
+	if e := m.handleKey("j"); e != effectLoadDetail || m.current().DcosAppId != "db2" {
+		t.Fatalf("j = %v on %s, want the detail of db2 loaded", e, m.current().DcosAppId)
+	}
+	m.details["db1"] = &detail{}
+	if e := m.handleKey(keyUp); e != effectNone || m.selected != 0 {
+		t.Errorf("up to a loaded workspace = %v, selected %d", e, m.selected)
+	}
+
+	// db1 is Active so it can't be resumed
+	if m.handleKey("r"); len(m.confirm) > 0 || !strings.Contains(m.message, "only Paused") {
+		t.Errorf("resume of an Active workspace: confirm %q, message %q", m.confirm, m.message)
+	}
+	m.handleKey("p")
+	if m.confirm != actionPause {
+		t.Fatalf("p asks %q, want pause", m.confirm)
+	}
+	if e := m.handleKey("n"); e != effectNone || len(m.confirm) > 0 {
+		t.Errorf("n = %v, confirm %q, want the pause cancelled", e, m.confirm)
+	}
+	m.handleKey("p")
+	// a refresh that drops db1 moves the selection, the pause is still of db1
+	m.setDatabases(&objects.DatabaseList{Clusters: []objects.CMClusterInfo{
+		{DcosAppId: "db2", Status: "Paused"},
+	}}, nil, time.Now())
+	if e := m.handleKey("y"); e != effectRunAction || m.running != actionPause || m.runningOn != "db1" {
+		t.Errorf("y = %v, running %q on %q", e, m.running, m.runningOn)
+	}
+	if m.handleKey("R"); len(m.confirm) > 0 {
+		t.Error("a second action was offered while one is running")
+	}
+
+	if e := m.handleKey("v"); e != effectLoadVersions || m.pane != paneVersions {
+		t.Errorf("v = %v, pane %v", e, m.pane)
+	}
+	m.setVersions(&objects.VaultVersionList{Versions: []objects.VaultVersion{{Version: 1}, {Version: 2}}}, nil)
+	if v, _ := m.selectedVersion(); v.Version != 2 {
+		t.Errorf("selected version %d, want the newest", v.Version)
+	}
+	if e := m.handleKey(keyEnter); e != effectLoadVersionCR || m.pane != paneVersionCR {
+		t.Errorf("enter = %v, pane %v", e, m.pane)
+	}
+	m.handleKey(keyEsc)
+	m.handleKey(keyEsc)
+	if m.pane != paneDetail {
+		t.Errorf("esc twice left pane %v", m.pane)
+	}
+	if e := m.handleKey("q"); e != effectQuit {
+		t.Errorf("q = %v", e)
+	}
+}
+
+func TestRender(t *testing.T) {
+	m := testModel()
+	m.details["db1"] = &detail{
+		status:     objects.DatabaseStatus{"phase": "Running"},
+		tags:       []objects.ImageTag{{Component: "hbase", ActiveImage: "a:1", DatabaseCRImage: "a:2"}},
+		components: []objects.ComponentCondition{{Name: "hbase", Enabled: true}, {Name: "kafka"}},
+	}
+	lines := m.render("title", 100, 30)
+	if len(lines) != 30 {
+		t.Fatalf("render() = %d lines, want 30", len(lines))
+	}
+	screen := strings.Join(lines, "\n")
+	for _, want := range []string{"WORKSPACES (2)", "> db1", "phase: Running", "a:1 (CR: a:2)", "✓ hbase", "✗ kafka", "p pause"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("the screen is missing %q:\n%s", want, screen)
+		}
+	}
+	for i, l := range lines {
+		if n := len([]rune(l)); n > 100 {
+			t.Errorf("line %d is %d wide", i, n)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+var (
+	boldStyle     = color.New(color.Bold)
+	dimStyle      = color.New(color.Faint)
+	selectedStyle = color.New(color.ReverseVideo)
+	errorStyle    = color.New(color.FgRed)
+	goodStyle     = color.New(color.FgGreen)
+	warnStyle     = color.New(color.FgYellow)
+)
+
+// line - a line of a pane, it is cut to the width of the pane before the
+// style is applied so the escape codes are never cut in half
+type line struct {
+	text  string
+	style *color.Color
+}
+
+// detailFields - the DatabaseFields shown for the selected workspace
+var detailFields = []struct {
+	label string
+	field string
+}{
+	{"Name", "name"},
+	{"Namespace", "namespace"},
+	{"Status", "status"},
+	{"Cluster ID", "cluster-id"},
+	{"Account", "account"},
+	{"Email", "email"},
+	{"Free tier", "free-tier"},
+	{"Created", "created"},
+	{"Updated", "updated"},
+}
+
+// render - the screen as height lines of at most width characters, the
+// selected workspace is listed on the left and the pane is on the right
+func (m *model) render(title string, width, height int) []string {
+	if width < 40 {
+		width = 40
+	}
+	if height < 6 {
+		height = 6
+	}
+	body := height - 3
+	listWidth := width / 3
+	if listWidth > 40 {
+		listWidth = 40
+	}
+	paneWidth := width - listWidth - 3
+
+	lines := make([]string, 0, height)
+	lines = append(lines, boldStyle.Sprint(fit(title, width)))
+	left := m.renderList(listWidth, body)
+	right := m.renderPane(paneWidth, body)
+	for i := 0; i < body; i++ {
+		lines = append(lines, left[i]+dimStyle.Sprint(" │ ")+right[i])
+	}
+	lines = append(lines, dimStyle.Sprint(strings.Repeat("─", width)))
+	lines = append(lines, m.footer(width))
+	return lines
+}
+
+// renderList - the workspaces and their status, scrolled so the selected
+// workspace is visible
+func (m *model) renderList(width, height int) []string {
+	lines := make([]string, 0, height)
+	heading := line{text: fmt.Sprintf("WORKSPACES (%d)", len(m.databases)), style: boldStyle}
+	if m.listErr != nil {
+		heading = line{text: fmt.Sprintf("WORKSPACES (%d) refresh failed: %v", len(m.databases), m.listErr), style: errorStyle}
+	}
+	lines = append(lines, heading.render(width))
+
+	rows := height - 1
+	offset := 0
+	if m.selected >= rows {
+		offset = m.selected - rows + 1
+	}
+	statusWidth := 10
+	nameWidth := width - statusWidth - 3
+	for i := offset; i < len(m.databases) && len(lines) < height; i++ {
+		db := m.databases[i]
+		marker, nameStyle := "  ", (*color.Color)(nil)
+		if i == m.selected {
+			marker, nameStyle = "> ", selectedStyle
+		}
+		name := line{text: db.DcosAppId, style: nameStyle}
+		status := line{text: db.Status, style: statusStyle(db.Status)}
+		lines = append(lines, marker+name.render(nameWidth)+" "+status.render(statusWidth))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+// renderPane - the right hand side, scrolled by m.scroll
+func (m *model) renderPane(width, height int) []string {
+	var content []line
+	offset := 0
+	switch {
+	case m.current() == nil:
+		content = m.detailLines()
+	case m.pane == paneVersions:
+		content = m.versionLines()
+		// keep the selected version visible, below its title and header
+		if m.version+3 > height {
+			offset = m.version + 3 - height
+		}
+	case m.pane == paneVersionCR, m.pane == paneLogs:
+		content = m.textLines()
+		offset = m.clampScroll(len(content), height)
+	default:
+		content = m.detailLines()
+		offset = m.clampScroll(len(content), height)
+	}
+
+	lines := make([]string, 0, height)
+	for i := offset; i < len(content) && len(lines) < height; i++ {
+		lines = append(lines, content[i].render(width))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", width))
+	}
+	return lines
+}
+
+// clampScroll - keeps the scroll within the content, so scrolling back
+// up starts at once
+func (m *model) clampScroll(lines, height int) int {
+	max := lines - height
+	if max < 0 {
+		max = 0
+	}
+	if m.scroll > max {
+		m.scroll = max
+	}
+	return m.scroll
+}
+
+// detailLines - the fields, status, image tags and enabled components of
+// the selected workspace
+func (m *model) detailLines() []line {
+	db := m.current()
+	if db == nil {
+		if m.listErr != nil {
+			return []line{{text: "No workspaces could be listed", style: errorStyle}}
+		}
+		return []line{{text: "Loading the workspaces..."}}
+	}
+
+	lines := []line{{text: "WORKSPACE", style: boldStyle}}
+	for _, f := range detailFields {
+		lines = append(lines, line{text: fmt.Sprintf("  %-11s %s", f.label+":", objects.DatabaseFields[f.field](*db))})
+	}
+	d, ok := m.details[db.DcosAppId]
+	if !ok {
+		return append(lines, line{}, line{text: "Loading the status, image tags and CR..."})
+	}
+
+	lines = append(lines, line{}, line{text: "STATUS", style: boldStyle})
+	if d.statusErr != nil {
+		lines = append(lines, errorLine(d.statusErr))
+	}
+	keys := make([]string, 0, len(d.status))
+	for k := range d.status {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, line{text: fmt.Sprintf("  %s: %s", k, valueText(d.status[k]))})
+	}
+
+	lines = append(lines, line{}, line{text: "IMAGE TAGS", style: boldStyle})
+	if d.tagsErr != nil {
+		lines = append(lines, errorLine(d.tagsErr))
+	}
+	for _, tag := range d.tags {
+		if tag.DatabaseCRImage != tag.ActiveImage {
+			lines = append(lines, line{text: fmt.Sprintf("  %-10s %s (CR: %s)", tag.Component, tag.ActiveImage, tag.DatabaseCRImage), style: warnStyle})
+			continue
+		}
+		lines = append(lines, line{text: fmt.Sprintf("  %-10s %s", tag.Component, tag.ActiveImage)})
+	}
+
+	lines = append(lines, line{}, line{text: "COMPONENTS", style: boldStyle})
+	if d.crErr != nil {
+		lines = append(lines, errorLine(d.crErr))
+	}
+	for _, component := range d.components {
+		if component.Enabled {
+			lines = append(lines, line{text: "  ✓ " + component.Name, style: goodStyle})
+		} else {
+			lines = append(lines, line{text: "  ✗ " + component.Name, style: dimStyle})
+		}
+	}
+	return lines
+}
+
+// versionLines - the versions of the CR of the selected workspace
+func (m *model) versionLines() []line {
+	lines := []line{{text: "CR VERSIONS of " + m.current().DcosAppId, style: boldStyle}}
+	switch {
+	case m.loading:
+		return append(lines, line{text: "Loading..."})
+	case m.loadErr != nil:
+		return append(lines, errorLine(m.loadErr))
+	case m.versions == nil || len(m.versions.Versions) == 0:
+		return append(lines, line{text: "No versions"})
+	}
+	lines = append(lines, line{text: fmt.Sprintf("  %-8s %-32s %-32s %s", "VERSION", "CREATED_AT", "DELETED_AT", "DESTROYED"), style: dimStyle})
+	for i, v := range m.versions.Versions {
+		text := fmt.Sprintf("  %-8d %-32s %-32s %t", v.Version, v.CreatedTime, v.DeletionTime, v.Destroyed)
+		if i == m.version {
+			lines = append(lines, line{text: text, style: selectedStyle})
+			continue
+		}
+		lines = append(lines, line{text: text})
+	}
+	return lines
+}
+
+// textLines - the CR of a version or the logs of the selected workspace
+func (m *model) textLines() []line {
+	title := "LOGS of " + m.current().DcosAppId
+	if m.pane == paneVersionCR {
+		v, _ := m.selectedVersion()
+		title = fmt.Sprintf("CR version %d of %s", v.Version, m.current().DcosAppId)
+	}
+	lines := []line{{text: title, style: boldStyle}}
+	switch {
+	case m.loading:
+		return append(lines, line{text: "Loading..."})
+	case m.loadErr != nil:
+		lines = append(lines, errorLine(m.loadErr))
+	}
+	for _, text := range m.lines {
+		lines = append(lines, line{text: text})
+	}
+	return lines
+}
+
+// footer - the confirmation prompt, the running action, the last message
+// or the keys of the pane
+func (m *model) footer(width int) string {
+	switch {
+	case len(m.confirm) > 0:
+		prompt := line{text: fmt.Sprintf("%s %s? [y/N]", strings.Title(string(m.confirm)), m.confirmOn), style: color.New(color.FgYellow, color.Bold)}
+		return prompt.render(width)
+	case len(m.message) > 0:
+		return line{text: m.message}.render(width)
+	case len(m.running) > 0:
+		return line{text: fmt.Sprintf("Running %s on %s...", m.running, m.runningOn), style: warnStyle}.render(width)
+	}
+	help := "↑/↓ select  p pause  r resume  R restart  v CR versions  l logs  g refresh  q quit"
+	switch m.pane {
+	case paneVersions:
+		help = "↑/↓ select  enter show the CR  esc back  q quit"
+	case paneVersionCR, paneLogs:
+		help = "↑/↓ pgup/pgdn home/end scroll  esc back  q quit"
+	}
+	return line{text: help, style: dimStyle}.render(width)
+}
+
+// render - the text cut or padded to width, then styled
+func (l line) render(width int) string {
+	text := fit(l.text, width)
+	if l.style == nil {
+		return text
+	}
+	return l.style.Sprint(text)
+}
+
+func errorLine(err error) line {
+	return line{text: "  " + err.Error(), style: errorStyle}
+}
+
+func statusStyle(status string) *color.Color {
+	switch status {
+	case "Active":
+		return goodStyle
+	case "Paused", "Pausing", "Resuming", "Restarting", "Pending", "Creating":
+		return warnStyle
+	}
+	return errorStyle
+}
+
+// valueText - a status value, anything but text is compact JSON
+func valueText(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(raw)
+}
+
+// fit - s without control characters, cut or padded to width
+func fit(s string, width int) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t':
+			return ' '
+		case r < ' ' || r == 0x7f:
+			return -1
+		}
+		return r
+	}, s)
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		runes := []rune(s)
+		if width < 1 {
+			return ""
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-n)
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/config"
+	"github.com/splicemachine/splicectl/common"
+	"golang.org/x/term"
+)
+
+// resizeCheck - how often the size of the terminal is checked
+const resizeCheck = 500 * time.Millisecond
+
+var uiCmd = &cobra.Command{
+	Use:   "ui",
+	Short: "A full-screen dashboard of the workspaces",
+	Long: `EXAMPLES
+	splicectl ui
+	splicectl ui --refresh-interval 30s --tail 500
+
+	Lists the workspaces on the left, the selected workspace is shown on the
+	right with its status, image tags and the components its CR enables.
+
+	KEYS
+	  ↑/↓ or k/j   select a workspace
+	  p            pause the workspace
+	  r            resume the workspace
+	  R            restart the workspace
+	  v            the versions of the database CR, enter shows one
+	  l            the last --tail lines of the logs of the --selector pods
+	  pgup/pgdn    scroll the logs, a CR or a long detail
+	  g            refresh now
+	  esc          back to the detail
+	  q            quit
+
+	Pause, resume and restart ask for confirmation and are recorded in the
+	audit log as 'ui pause', 'ui resume' and 'ui restart'.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c.VersionDetail.RequirementMet("ui")
+
+		interval, _ := cmd.Flags().GetDuration("refresh-interval")
+		selector, _ := cmd.Flags().GetString("selector")
+		tail, _ := cmd.Flags().GetInt64("tail")
+
+		if !common.IsTerminal(os.Stdin) || !common.IsTerminal(os.Stdout) {
+			c.FatalError(errors.New("splicectl ui needs a terminal"), "Could not start the dashboard")
+		}
+		if interval <= 0 {
+			c.FatalError(fmt.Errorf("--refresh-interval must be positive, got %s", interval), "Could not start the dashboard")
+		}
+
+		d := &dashboard{
+			m:        newModel(),
+			api:      c.APIClient(),
+			updates:  make(chan func(*model), 16),
+			selector: selector,
+			tail:     tail,
+		}
+		if err := d.run(interval); err != nil {
+			c.FatalError(err, "The dashboard failed")
+		}
+	},
+}
+
+// run - draws the dashboard until it is quit, the terminal is restored on
+// the way out and a running action is waited for
+func (d *dashboard) run(interval time.Duration) error {
+	defer d.wait()
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	// log output would scribble over the screen
+	logOutput := logrus.StandardLogger().Out
+	logrus.SetOutput(ioutil.Discard)
+	defer logrus.SetOutput(logOutput)
+
+	// the alternate screen keeps the scrollback of the shell intact
+	fmt.Fprint(os.Stdout, "\033[?1049h\033[?25l")
+	defer fmt.Fprint(os.Stdout, "\033[?25h\033[?1049l")
+
+	keys := make(chan key)
+	go readKeys(os.Stdin, keys)
+
+	refresh := time.NewTicker(interval)
+	defer refresh.Stop()
+	resize := time.NewTicker(resizeCheck)
+	defer resize.Stop()
+
+	title := "splicectl ui: " + c.ApiServer
+	if c.Context != nil {
+		title = fmt.Sprintf("splicectl ui: %s (%s)", c.Context.Name, c.ApiServer)
+	}
+
+	d.refresh()
+	width, height := terminalSize()
+	d.draw(title, width, height)
+	for {
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			if quit := d.apply(d.m.handleKey(k)); quit {
+				return nil
+			}
+		case update := <-d.updates:
+			update(d.m)
+		case <-refresh.C:
+			d.refresh()
+			continue
+		case <-resize.C:
+			if w, h := terminalSize(); w == width && h == height {
+				continue
+			}
+		}
+		width, height = terminalSize()
+		d.draw(title, width, height)
+	}
+}
+
+// wait - waits for the running action to finish, the model may still show
+// one that just finished as its update is no longer read
+func (d *dashboard) wait() {
+	if len(d.m.running) > 0 {
+		logrus.Infof("Waiting for the %s of %s to finish", d.m.running, d.m.runningOn)
+	}
+	d.actions.Wait()
+}
+
+// terminalSize - the size of the terminal, 80x24 when it can't be read
+func terminalSize() (int, int) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 80, 24
+	}
+	return width, height
+}
+
+// apply - starts the work a key asked for, true when the dashboard quits
+func (d *dashboard) apply(e effect) bool {
+	db := d.m.current()
+	switch e {
+	case effectQuit:
+		return true
+	case effectRefresh:
+		d.refresh()
+	case effectLoadDetail:
+		d.loadDetail(db.DcosAppId)
+	case effectLoadVersions:
+		d.loadVersions(db.DcosAppId)
+	case effectLoadVersionCR:
+		if v, ok := d.m.selectedVersion(); ok {
+			d.loadVersionCR(db.DcosAppId, v.Version)
+		}
+	case effectLoadLogs:
+		d.loadLogs(db.Namespace)
+	case effectRunAction:
+		d.runAction(d.m.running, d.m.runningOn)
+	}
+	return false
+}
+
+// draw - writes the screen from the top left corner, each line clears what
+// is left of the previous frame so the screen does not flicker
+func (d *dashboard) draw(title string, width, height int) {
+	lines := d.m.render(fmt.Sprintf("%s  refreshed %s", title, d.m.refreshed.Format("15:04:05")), width, height)
+	fmt.Fprint(os.Stdout, "\033[H"+strings.Join(lines, "\033[K\r\n")+"\033[K\033[J")
+}
+
+var c *config.Config
+
+func InitSubCommands(conf *config.Config) *cobra.Command {
+	c = conf
+	return uiCmd
+}
+
+func init() {
+	uiCmd.Flags().Duration("refresh-interval", 10*time.Second, "How often the workspaces and the selected detail are reloaded")
+	uiCmd.Flags().StringP("selector", "s", "app=hbase", "kubernetes selector expression of the pods whose logs are shown")
+	uiCmd.Flags().Int64("tail", 200, "The lines of each container log that are shown")
+}
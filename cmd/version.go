@@ -23,12 +23,8 @@ var versionCmd = &cobra.Command{
 		case "json":
 			// We want to print the JSON in a condensed format
 			fmt.Println(c.VersionJSON)
-		case "gron":
-			fmt.Println(c.VersionDetail.ToGRON())
-		case "yaml":
-			fmt.Println(c.VersionDetail.ToYAML())
-		case "text", "table":
-			fmt.Println(c.VersionDetail.ToTEXT(c.NoHeaders))
+		default:
+			fmt.Println(c.Render(&c.VersionDetail))
 		}
 	},
 }
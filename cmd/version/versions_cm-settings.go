@@ -1,13 +1,14 @@
 package version
 
 import (
-	"fmt"
-	"os"
+	"context"
+	"encoding/json"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
-	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,7 @@ var versionsCMSettingsCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl versions cm-settings --component ui
 	splicectl versions cm-settings --component api
+	splicectl versions cm-settings --component ui --diff latest~1..latest
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("versions_cm-settings")
@@ -28,49 +30,33 @@ var versionsCMSettingsCmd = &cobra.Command{
 		if len(component) == 0 || !strings.Contains("ui api", component) {
 			logrus.Fatal("--component needs to be 'ui' or 'api'")
 		}
-		out, err := getCMSettingsVersions(component)
+		resource := client.CMSettings(component)
+		out, raw, err := c.APIClient().ListVersionsRaw(context.TODO(), resource)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting CM Settings")
+			c.FatalError(err, "Error getting CM Settings")
+		}
+
+		if diffSpec, _ := cmd.Flags().GetString("diff"); len(diffSpec) > 0 {
+			c.ShowVersionDiff(resource, out, diffSpec)
+			return
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.1.6"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayVersionsCmSettingsV1(out)
+				displayVersionsCmSettingsV1(out, raw)
 			}
 		}
 	},
 }
 
-func displayVersionsCmSettingsV1(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	ssData, cerr := common.RestructureVersions(in)
-	if cerr != nil {
-		logrus.Fatal("Vault Version JSON conversion failed.")
-	}
-
-	c.OutputData(&ssData)
-}
-
-func getCMSettingsVersions(comp string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/cmsettingsversions?component=%s", comp)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayVersionsCmSettingsV1(out *objects.VaultVersionList, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	versionsCmd.AddCommand(versionsCMSettingsCmd)
 	versionsCMSettingsCmd.Flags().StringP("component", "c", "", "Specify the component, <ui|api>")
+	versionsCMSettingsCmd.Flags().String("diff", "", "Show the changes between two versions, ie: 3..5 or latest~1..latest")
 }
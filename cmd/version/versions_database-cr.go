@@ -1,12 +1,14 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
 	"github.com/splicemachine/splicectl/common"
 
 	"github.com/spf13/cobra"
@@ -18,6 +20,7 @@ var versionsDatabaseCRCmd = &cobra.Command{
 	Long: `EXAMPLES
 	splicectl list workspace
 	splicectl versions workspace-cr --database-name splicedb
+	splicectl versions workspace-cr --database-name splicedb --diff 3..5
 
 	Note: --database-name and -d are the preferred way to supply the database name.
 	However, --database and --workspace can also be used as well. In the event that
@@ -34,19 +37,25 @@ var versionsDatabaseCRCmd = &cobra.Command{
 		if len(databaseName) == 0 {
 			databaseName, dberr = c.PromptForDatabaseName()
 			if dberr != nil {
-				logrus.Fatal("Could not get a list of workspaces", dberr)
+				c.FatalError(dberr, "Could not get a list of workspaces")
 			}
 		}
-		out, err := getDatabaseCRVersions(databaseName)
+		resource := client.DatabaseCR(databaseName)
+		out, raw, err := c.APIClient().ListVersionsRaw(context.TODO(), resource)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting workspace CR versions")
+			c.FatalError(err, "Error getting workspace CR versions")
+		}
+
+		if diffSpec, _ := cmd.Flags().GetString("diff"); len(diffSpec) > 0 {
+			c.ShowVersionDiff(resource, out, diffSpec)
+			return
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayVersionsDatabaseCRV1(out)
+				displayVersionsDatabaseCRV1(raw)
 			}
 		}
 
@@ -54,42 +63,18 @@ var versionsDatabaseCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayVersionsDatabaseCRV2(out)
+				displayVersionsDatabaseCRV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayVersionsDatabaseCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayVersionsDatabaseCRV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	crData, cerr := common.RestructureVersions(in)
-	if cerr != nil {
-		logrus.Fatal("Vault Version JSON conversion failed.")
-	}
-
-	c.OutputData(&crData)
+func displayVersionsDatabaseCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func getDatabaseCRVersions(db string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/databasecrversions?database-name=%s", db)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting workspace CR Versions")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayVersionsDatabaseCRV2(out *objects.VaultVersionList, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -102,4 +87,5 @@ func init() {
 
 	// versionsDatabaseCRCmd.Flags().String("output", "json", "Specify the output type")
 	// versionsDatabaseCRCmd.MarkFlagRequired("database-name")
+	versionsDatabaseCRCmd.Flags().String("diff", "", "Show the changes between two versions, ie: 3..5 or latest~1..latest")
 }
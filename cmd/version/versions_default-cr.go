@@ -1,15 +1,16 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
-	"github.com/splicemachine/splicectl/common"
 )
 
 var versionsDefaultCRCmd = &cobra.Command{
@@ -17,20 +18,28 @@ var versionsDefaultCRCmd = &cobra.Command{
 	Short: "Retrieve the versions of the default CR in the cluster.",
 	Long: `EXAMPLES
 	splicectl versions default-cr
+	splicectl versions default-cr --diff 3..5
+	splicectl versions default-cr --diff latest~1..latest
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("versions_default-cr")
 
-		out, err := getDefaultCRVersions()
+		resource := client.DefaultCR()
+		out, raw, err := c.APIClient().ListVersionsRaw(context.TODO(), resource)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error getting Default CR Info")
+		}
+
+		if diffSpec, _ := cmd.Flags().GetString("diff"); len(diffSpec) > 0 {
+			c.ShowVersionDiff(resource, out, diffSpec)
+			return
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayVersionsDefaultCRV1(out)
+				displayVersionsDefaultCRV1(raw)
 			}
 		}
 
@@ -38,45 +47,22 @@ var versionsDefaultCRCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayVersionsDefaultCRV2(out)
+				displayVersionsDefaultCRV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayVersionsDefaultCRV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayVersionsDefaultCRV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	crData, cerr := common.RestructureVersions(in)
-	if cerr != nil {
-		logrus.Fatal("Vault Version JSON conversion failed.")
-	}
-
-	c.OutputData(&crData)
+func displayVersionsDefaultCRV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func getDefaultCRVersions() (string, error) {
-	uri := "splicectl/v1/vault/defaultcrversions"
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayVersionsDefaultCRV2(out *objects.VaultVersionList, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	versionsCmd.AddCommand(versionsDefaultCRCmd)
 
+	versionsDefaultCRCmd.Flags().String("diff", "", "Show the changes between two versions, ie: 3..5 or latest~1..latest")
 }
@@ -1,13 +1,14 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
-	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
 )
@@ -17,20 +18,27 @@ var versionsSystemSettingsCmd = &cobra.Command{
 	Short: "Retrieve the versions of the system settings in the cluster.",
 	Long: `EXAMPLES
 	splicectl versions system-settings
+	splicectl versions system-settings --diff latest~1..latest
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("versions_system-settings")
 
-		out, err := getSystemSettingsVersions()
+		resource := client.SystemSettings()
+		out, raw, err := c.APIClient().ListVersionsRaw(context.TODO(), resource)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting System Settings")
+			c.FatalError(err, "Error getting System Settings")
+		}
+
+		if diffSpec, _ := cmd.Flags().GetString("diff"); len(diffSpec) > 0 {
+			c.ShowVersionDiff(resource, out, diffSpec)
+			return
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayVersionsSystemSettingsV1(out)
+				displayVersionsSystemSettingsV1(raw)
 			}
 		}
 
@@ -38,45 +46,22 @@ var versionsSystemSettingsCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayVersionsSystemSettingsV2(out)
+				displayVersionsSystemSettingsV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayVersionsSystemSettingsV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayVersionsSystemSettingsV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	ssData, cerr := common.RestructureVersions(in)
-	if cerr != nil {
-		logrus.Fatal("Vault Version JSON conversion failed.")
-	}
-
-	c.OutputData(&ssData)
+func displayVersionsSystemSettingsV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func getSystemSettingsVersions() (string, error) {
-	uri := "splicectl/v1/vault/systemsettingsversions"
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting System Settings")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayVersionsSystemSettingsV2(out *objects.VaultVersionList, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
 	versionsCmd.AddCommand(versionsSystemSettingsCmd)
 
+	versionsSystemSettingsCmd.Flags().String("diff", "", "Show the changes between two versions, ie: 3..5 or latest~1..latest")
 }
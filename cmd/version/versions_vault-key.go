@@ -1,13 +1,15 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/sirupsen/logrus"
-	"github.com/splicemachine/splicectl/common"
+	"github.com/splicemachine/splicectl/client"
+	"github.com/splicemachine/splicectl/cmd/objects"
 
 	"github.com/spf13/cobra"
 )
@@ -17,6 +19,7 @@ var versionsVaultKeyCmd = &cobra.Command{
 	Short: "Retrieve the versions of a specified vault key from the cluster.",
 	Long: `EXAMPLES
 	splicectl versions vault-key --keypath services/cloudmanager/config/default/ui
+	splicectl versions vault-key --keypath services/cloudmanager/config/default/ui --diff 2..latest
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 		_, sv := c.VersionDetail.RequirementMet("versions_vault-key")
@@ -25,16 +28,22 @@ var versionsVaultKeyCmd = &cobra.Command{
 		if strings.HasPrefix(keyPath, "secrets/") {
 			keyPath = strings.TrimPrefix(keyPath, "secrets/")
 		}
-		out, err := getVaultKeyVersionData(keyPath)
+		resource := client.VaultKey(keyPath)
+		out, raw, err := c.APIClient().ListVersionsRaw(context.TODO(), resource)
 		if err != nil {
-			logrus.WithError(err).Error("Error getting Default CR Info")
+			c.FatalError(err, "Error getting Vault Key Versions")
+		}
+
+		if diffSpec, _ := cmd.Flags().GetString("diff"); len(diffSpec) > 0 {
+			c.ShowVersionDiff(resource, out, diffSpec)
+			return
 		}
 
 		if semverV1, err := semver.ParseRange(">=0.0.15 <0.0.17"); err != nil {
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV1(sv) {
-				displayVersionsVaultKeyV1(out)
+				displayVersionsVaultKeyV1(raw)
 			}
 		}
 
@@ -42,42 +51,18 @@ var versionsVaultKeyCmd = &cobra.Command{
 			logrus.Fatal("Failed to parse SemVer")
 		} else {
 			if semverV2(sv) {
-				displayVersionsVaultKeyV2(out)
+				displayVersionsVaultKeyV2(out, raw)
 			}
 		}
 	},
 }
 
-func displayVersionsVaultKeyV1(in string) {
-	fmt.Println(in)
-	os.Exit(0)
-}
-
-func displayVersionsVaultKeyV2(in string) {
-	if strings.ToLower(c.OutputFormat) == "raw" {
-		fmt.Println(in)
-		os.Exit(0)
-	}
-	vkData, cerr := common.RestructureVersions(in)
-	if cerr != nil {
-		logrus.Fatal("Vault Version JSON conversion failed.")
-	}
-
-	c.OutputData(&vkData)
+func displayVersionsVaultKeyV1(raw json.RawMessage) {
+	fmt.Println(string(raw))
 }
 
-func getVaultKeyVersionData(keypath string) (string, error) {
-	uri := fmt.Sprintf("splicectl/v1/vault/vaultkeyversions?keypath=%s", keypath)
-	resp, resperr := c.RestyWithHeaders().
-		Get(fmt.Sprintf("%s/%s", c.ApiServer, uri))
-
-	if resperr != nil {
-		logrus.WithError(resperr).Error("Error getting Default CR Info")
-		return "", resperr
-	}
-
-	return string(resp.Body()[:]), nil
-
+func displayVersionsVaultKeyV2(out *objects.VaultVersionList, raw json.RawMessage) {
+	c.OutputRaw(out, raw)
 }
 
 func init() {
@@ -85,4 +70,5 @@ func init() {
 
 	versionsVaultKeyCmd.Flags().String("keypath", "", "Specify the vault key path")
 	versionsVaultKeyCmd.MarkFlagRequired("keypath")
+	versionsVaultKeyCmd.Flags().String("diff", "", "Show the changes between two versions, ie: 3..5 or latest~1..latest")
 }
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Archive - a gzipped tarball that files are added to from many goroutines,
+// every entry is kept under a directory named after the archive
+type Archive struct {
+	mu     sync.Mutex
+	file   *os.File
+	gz     *gzip.Writer
+	tw     *tar.Writer
+	prefix string
+	dir    string
+}
+
+// ArchivePrefix - the directory inside an archive, its name without
+// .tar.gz or .tgz
+func ArchivePrefix(name string) string {
+	base := filepath.Base(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".gz"} {
+		if strings.HasSuffix(base, ext) {
+			return strings.TrimSuffix(base, ext)
+		}
+	}
+	return base
+}
+
+// CreateArchive - creates the archive at name, an existing file is only
+// replaced when overwrite is set
+func CreateArchive(name string, overwrite bool) (*Archive, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	file, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("'%s' already exists, use --overwrite to replace it", name)
+		}
+		return nil, fmt.Errorf("%v; could not create archive: %s", err, name)
+	}
+	gz := gzip.NewWriter(file)
+	return &Archive{file: file, gz: gz, tw: tar.NewWriter(gz), prefix: ArchivePrefix(name), dir: filepath.Dir(name)}, nil
+}
+
+// Create - a writer for the entry name, it is spooled to a temporary file
+// next to the archive as the size of an entry must be known before it is
+// added, and is added when the writer is closed.  The directory of the
+// archive needs room for the entries being written at once.
+func (a *Archive) Create(name string) (io.WriteCloser, error) {
+	tmp, err := ioutil.TempFile(a.dir, ".splicectl-archive-")
+	if err != nil {
+		return nil, err
+	}
+	return &archiveEntry{archive: a, name: name, tmp: tmp}, nil
+}
+
+// Add - adds the entry name holding data
+func (a *Archive) Add(name string, data []byte) error {
+	return a.add(name, int64(len(data)), bytes.NewReader(data))
+}
+
+func (a *Archive) add(name string, size int64, r io.Reader) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	hdr := &tar.Header{
+		Name:    path.Join(a.prefix, filepath.ToSlash(name)),
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("%v; could not add %s to the archive", err, name)
+	}
+	if _, err := io.Copy(a.tw, r); err != nil {
+		return fmt.Errorf("%v; could not add %s to the archive", err, name)
+	}
+	return nil
+}
+
+// Close - writes the end of the archive, no entry can be added after
+func (a *Archive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.tw.Close(); err != nil {
+		a.file.Close()
+		return err
+	}
+	if err := a.gz.Close(); err != nil {
+		a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
+
+// archiveEntry - an entry being written, see Archive.Create
+type archiveEntry struct {
+	archive *Archive
+	name    string
+	tmp     *os.File
+}
+
+func (ae *archiveEntry) Write(p []byte) (int, error) {
+	return ae.tmp.Write(p)
+}
+
+func (ae *archiveEntry) Close() error {
+	defer os.Remove(ae.tmp.Name())
+	defer ae.tmp.Close()
+	size, err := ae.tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := ae.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return ae.archive.add(ae.name, size, ae.tmp)
+}
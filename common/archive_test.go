@@ -0,0 +1,75 @@
+package common
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	a, err := CreateArchive(name, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := a.Create("pod/hbase.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, "line 1\nline 2\n")
+	// the entry is spooled next to the archive
+	if spooled, _ := filepath.Glob(filepath.Join(filepath.Dir(name), ".splicectl-archive-*")); len(spooled) != 1 {
+		t.Errorf("want the entry spooled next to the archive, found %v", spooled)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if spooled, _ := filepath.Glob(filepath.Join(filepath.Dir(name), ".splicectl-archive-*")); len(spooled) != 0 {
+		t.Errorf("the spooled entries were not removed: %v", spooled)
+	}
+	if err := a.Add("manifest.json", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := ioutil.ReadAll(tr)
+		got[hdr.Name] = string(data)
+	}
+	if got["bundle/pod/hbase.log"] != "line 1\nline 2\n" || got["bundle/manifest.json"] != "{}" || len(got) != 2 {
+		t.Errorf("unexpected entries %v", got)
+	}
+
+	if _, err := CreateArchive(name, false); err == nil {
+		t.Error("an existing archive should not be replaced without overwrite")
+	}
+	a, err = CreateArchive(name, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Close()
+}
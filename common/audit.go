@@ -0,0 +1,149 @@
+package common
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+const (
+	// AuditMaxSize - the audit log is rotated once it would grow past this
+	AuditMaxSize = 10 * 1024 * 1024
+	// AuditMaxBackups - rotated logs kept as audit.log.1 (newest) and up
+	AuditMaxBackups = 5
+	// Redacted - replaces secret values in the audit log and support bundles
+	Redacted = "REDACTED"
+)
+
+// secretName - flag and argument names whose values are never logged
+var secretName = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|session|private|apikey|api-key)`)
+
+// AuditLogPath - ~/.splicectl/audit.log
+func AuditLogPath() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".splicectl", "audit.log"), nil
+}
+
+// AuditArgs - the positional arguments and the flags that were set on cmd,
+// as --name=value, with the values of secret looking names redacted
+func AuditArgs(cmd *cobra.Command, args []string) []string {
+	out := make([]string, 0, len(args))
+	for _, arg := range args {
+		if kv := strings.SplitN(arg, "=", 2); len(kv) == 2 && secretName.MatchString(kv[0]) {
+			arg = kv[0] + "=" + Redacted
+		}
+		out = append(out, arg)
+	}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if secretName.MatchString(f.Name) {
+			value = Redacted
+		}
+		out = append(out, fmt.Sprintf("--%s=%s", f.Name, value))
+	})
+	return out
+}
+
+// AppendAuditRecord - adds rec as one JSON line to the log at path, rotating
+// the log first when it is full
+func AppendAuditRecord(path string, rec *objects.AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if info, err := os.Stat(path); err == nil && info.Size()+int64(len(line)) > AuditMaxSize {
+		if err := rotateAuditLog(path, AuditMaxBackups); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(line)
+	return err
+}
+
+// rotateAuditLog - path becomes path.1, path.1 becomes path.2 and so on,
+// the oldest is dropped
+func rotateAuditLog(path string, backups int) error {
+	os.Remove(fmt.Sprintf("%s.%d", path, backups))
+	for i := backups - 1; i >= 1; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(path, path+".1")
+}
+
+// ReadAuditLog - every record in the log at path and its rotated copies,
+// oldest first.  Lines that can't be decoded are skipped.
+func ReadAuditLog(path string) ([]objects.AuditRecord, error) {
+	records := []objects.AuditRecord{}
+	files := []string{}
+	for i := AuditMaxBackups; i >= 1; i-- {
+		files = append(files, fmt.Sprintf("%s.%d", path, i))
+	}
+	files = append(files, path)
+
+	for _, name := range files {
+		f, err := os.Open(name)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			var rec objects.AuditRecord
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err == nil {
+				records = append(records, rec)
+			}
+		}
+		serr := scanner.Err()
+		f.Close()
+		if serr != nil {
+			return nil, fmt.Errorf("%v; could not read %s", serr, name)
+		}
+	}
+	return records, nil
+}
+
+// ParseSince - the start of a --since window, either a duration back from
+// now (90m, 24h, 7d) or a date/time (2021-06-01, 2021-06-01T15:04:05Z)
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(since, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(since, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(since); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not a duration (24h, 7d) or a date (2006-01-02)", since)
+}
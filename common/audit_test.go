@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+func TestAuditArgs(t *testing.T) {
+	cmd := &cobra.Command{Use: "apply"}
+	cmd.Flags().String("keypath", "", "")
+	cmd.Flags().String("password", "", "")
+	cmd.Flags().String("file", "", "")
+	if err := cmd.ParseFlags([]string{"--keypath", "a/b", "--password", "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	args := AuditArgs(cmd, []string{"ui", "token=abc"})
+	expected := []string{"ui", "token=" + Redacted, "--keypath=a/b", "--password=" + Redacted}
+	if fmt.Sprint(args) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, args)
+	}
+}
+
+func TestAppendAuditRecordRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	big := []string{strings.Repeat("x", AuditMaxSize/3)}
+
+	for i := 0; i < 5; i++ {
+		rec := &objects.AuditRecord{Command: fmt.Sprintf("pause %d", i), Args: big}
+		if err := AppendAuditRecord(path, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(path + ".2"); err != nil {
+		t.Fatalf("expected the log to be rotated twice: %v", err)
+	}
+
+	records, err := ReadAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 || records[0].Command != "pause 0" || records[4].Command != "pause 4" {
+		t.Errorf("expected 5 records oldest first, got %d", len(records))
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2021, 6, 10, 12, 0, 0, 0, time.UTC)
+	for since, expected := range map[string]time.Time{
+		"90m":                  now.Add(-90 * time.Minute),
+		"7d":                   now.AddDate(0, 0, -7),
+		"2021-06-01T00:00:00Z": time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := ParseSince(since, now)
+		if err != nil || !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v %v", since, expected, got, err)
+		}
+	}
+	if _, err := ParseSince("yesterday", now); err == nil {
+		t.Error("expected an error for 'yesterday'")
+	}
+}
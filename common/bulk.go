@@ -0,0 +1,152 @@
+package common
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+// BulkOptions - selects many workspaces for one command instead of
+// --database-name, and how the command is run across them.  The selectors
+// that are given must all match.
+type BulkOptions struct {
+	AllActive       bool
+	AllPaused       bool
+	AccountID       string
+	NameRegex       *regexp.Regexp
+	FromFile        string
+	MaxConcurrency  int
+	ContinueOnError bool
+	Yes             bool
+}
+
+// AddBulkFlags - add the selectors, --max-concurrency, --continue-on-error
+// and --yes to cmd
+func AddBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all-active", false, "Select every Active workspace")
+	cmd.Flags().Bool("all-paused", false, "Select every Paused workspace")
+	cmd.Flags().String("account-id", "", "Select the workspaces of an account, by id or name")
+	cmd.Flags().String("name-regex", "", "Select the workspaces whose name matches a regular expression")
+	cmd.Flags().String("from-file", "", "Select the workspaces named in a file, one per line, - reads stdin")
+	cmd.Flags().Int("max-concurrency", 5, "How many selected workspaces are worked on at once")
+	cmd.Flags().Bool("continue-on-error", false, "Keep going when a selected workspace fails, by default no more are started")
+	cmd.Flags().BoolP("yes", "y", false, "Run on the selected workspaces without asking for confirmation")
+}
+
+// BulkFlags - the values of the flags added by AddBulkFlags, a selector
+// can't be combined with a database name
+func BulkFlags(cmd *cobra.Command) (BulkOptions, error) {
+	opts := BulkOptions{}
+	opts.AllActive, _ = cmd.Flags().GetBool("all-active")
+	opts.AllPaused, _ = cmd.Flags().GetBool("all-paused")
+	opts.AccountID, _ = cmd.Flags().GetString("account-id")
+	opts.FromFile, _ = cmd.Flags().GetString("from-file")
+	opts.MaxConcurrency, _ = cmd.Flags().GetInt("max-concurrency")
+	opts.ContinueOnError, _ = cmd.Flags().GetBool("continue-on-error")
+	opts.Yes, _ = cmd.Flags().GetBool("yes")
+
+	if expr, _ := cmd.Flags().GetString("name-regex"); len(expr) > 0 {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --name-regex: %w", err)
+		}
+		opts.NameRegex = re
+	}
+	if opts.AllActive && opts.AllPaused {
+		return opts, errors.New("--all-active and --all-paused can not be combined")
+	}
+	if opts.MaxConcurrency < 1 {
+		return opts, fmt.Errorf("--max-concurrency must be at least 1, got %d", opts.MaxConcurrency)
+	}
+	if opts.Selected() {
+		for _, name := range []string{"database-name", "workspace", "database"} {
+			if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+				return opts, fmt.Errorf("--%s can not be combined with --all-active, --all-paused, --account-id, --name-regex or --from-file", name)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// Selected - true when any selector was given
+func (o BulkOptions) Selected() bool {
+	return o.AllActive || o.AllPaused || len(o.AccountID) > 0 || o.NameRegex != nil || len(o.FromFile) > 0
+}
+
+// Select - the workspaces of list matching every selector, sorted by name.
+// A name from --from-file that is not in the list is an error.
+func (o BulkOptions) Select(list *objects.DatabaseList) ([]objects.CMClusterInfo, error) {
+	var named map[string]bool
+	if len(o.FromFile) > 0 {
+		names, err := ReadNames(o.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		named = map[string]bool{}
+		for _, name := range names {
+			named[name] = true
+		}
+		missing := []string{}
+		for _, name := range names {
+			if list.Find(name) == nil {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("%s names workspaces that do not exist: %s", o.FromFile, strings.Join(missing, ", "))
+		}
+	}
+
+	selected := []objects.CMClusterInfo{}
+	for _, db := range list.Clusters {
+		switch {
+		case o.AllActive && !strings.EqualFold(db.Status, "Active"):
+		case o.AllPaused && !strings.EqualFold(db.Status, "Paused"):
+		case len(o.AccountID) > 0 && !strings.EqualFold(db.Account.AccountId, o.AccountID) && !strings.EqualFold(db.Account.AccountName, o.AccountID):
+		case o.NameRegex != nil && !o.NameRegex.MatchString(db.DcosAppId):
+		case named != nil && !named[db.DcosAppId]:
+		default:
+			selected = append(selected, db)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].DcosAppId < selected[j].DcosAppId })
+	return selected, nil
+}
+
+// ReadNames - the workspace names in the file at path, one per line, blank
+// lines and lines starting with # are ignored.  - reads stdin.
+func ReadNames(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	names := []string{}
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if len(name) == 0 || strings.HasPrefix(name, "#") || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%v; could not read %s", err, path)
+	}
+	return names, nil
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+func TestBulkSelect(t *testing.T) {
+	list := &objects.DatabaseList{Clusters: []objects.CMClusterInfo{
+		{DcosAppId: "dev-b", Status: "Active", Account: objects.CMAccount{AccountId: "1", AccountName: "acme"}},
+		{DcosAppId: "dev-a", Status: "Paused", Account: objects.CMAccount{AccountId: "1", AccountName: "acme"}},
+		{DcosAppId: "prod", Status: "Active", Account: objects.CMAccount{AccountId: "2", AccountName: "other"}},
+	}}
+	file := filepath.Join(t.TempDir(), "names.txt")
+	if err := os.WriteFile(file, []byte("# nightly\ndev-b\n\nprod\ndev-b\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(opts BulkOptions) string {
+		t.Helper()
+		selected, err := opts.Select(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := []string{}
+		for _, db := range selected {
+			out = append(out, db.DcosAppId)
+		}
+		return strings.Join(out, ",")
+	}
+	for _, tc := range []struct {
+		opts BulkOptions
+		want string
+	}{
+		{BulkOptions{AllActive: true}, "dev-b,prod"},
+		{BulkOptions{AccountID: "ACME"}, "dev-a,dev-b"},
+		{BulkOptions{AccountID: "2"}, "prod"},
+		{BulkOptions{NameRegex: regexp.MustCompile("^dev-")}, "dev-a,dev-b"},
+		{BulkOptions{AllActive: true, NameRegex: regexp.MustCompile("^dev-")}, "dev-b"},
+		{BulkOptions{FromFile: file}, "dev-b,prod"},
+	} {
+		if got := names(tc.opts); got != tc.want {
+			t.Errorf("Select(%+v) = %s, expected %s", tc.opts, got, tc.want)
+		}
+	}
+
+	if err := os.WriteFile(file, []byte("dev-a\ngone\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (BulkOptions{FromFile: file}).Select(list); err == nil || !strings.Contains(err.Error(), "gone") {
+		t.Errorf("Select() of a missing workspace = %v, expected an error naming it", err)
+	}
+	if got, _ := ReadNames(file); !reflect.DeepEqual(got, []string{"dev-a", "gone"}) {
+		t.Errorf("ReadNames() = %v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// unsafeName - characters that are not kept in a cache file name
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Cache - values stored on disk as JSON for a while, a cache is kept per
+// context so clusters never see each other's answers
+type Cache struct {
+	Dir string
+}
+
+// cacheEntry - a value and when it was stored
+type cacheEntry struct {
+	Stored time.Time       `json:"stored"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// CacheRoot - ~/.splicectl/cache, the directory of every context's cache
+func CacheRoot() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".splicectl", "cache"), nil
+}
+
+// CacheDir - ~/.splicectl/cache/<context>, contexts without a name share
+// the 'default' directory
+func CacheDir(context string) (string, error) {
+	root, err := CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	if len(context) == 0 {
+		context = "default"
+	}
+	return filepath.Join(root, unsafeName.ReplaceAllString(context, "_")), nil
+}
+
+// NewCache - a cache in the directory of a context
+func NewCache(context string) (*Cache, error) {
+	dir, err := CacheDir(context)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, unsafeName.ReplaceAllString(key, "_")+".json")
+}
+
+// Get - decodes the value stored under key into v, false when there is none
+// or it is older than ttl
+func (c *Cache) Get(key string, ttl time.Duration, v interface{}) bool {
+	raw, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+	entry := cacheEntry{}
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return false
+	}
+	if time.Since(entry.Stored) > ttl {
+		return false
+	}
+	return json.Unmarshal(entry.Value, v) == nil
+}
+
+// Put - stores v under key, the file is replaced in one step so a reader
+// never sees half of it
+func (c *Cache) Put(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(cacheEntry{Stored: time.Now().UTC(), Value: value})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(c.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Invalidate - removes the values whose key starts with one of prefixes
+func (c *Cache) Invalidate(prefixes ...string) error {
+	files, err := ioutil.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(f.Name(), unsafeName.ReplaceAllString(prefix, "_")) {
+				if err := os.Remove(filepath.Join(c.Dir, f.Name())); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Clear - removes every value of the cache
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.Dir)
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	cache := &Cache{Dir: t.TempDir()}
+	got := []string{}
+	if cache.Get("databases", time.Minute, &got) {
+		t.Error("Get() of a missing key succeeded")
+	}
+
+	want := []string{"db1\tActive", "db2\tPaused"}
+	if err := cache.Put("databases", want); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	if !cache.Get("databases", time.Minute, &got) || !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %v, expected %v", got, want)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if cache.Get("databases", 5*time.Millisecond, &got) {
+		t.Error("Get() returned a stale value")
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// DiffJSON - the structural differences between two JSON documents, key
+// order is ignored.  An empty document is treated as null so a new vault key
+// shows up as fully added.
+func DiffJSON(from, to []byte) (*objects.DiffChangeList, error) {
+	var fromData, toData interface{}
+	if len(from) > 0 {
+		if err := json.Unmarshal(from, &fromData); err != nil {
+			return nil, fmt.Errorf("%v; could not decode the current document", err)
+		}
+	}
+	if len(to) > 0 {
+		if err := json.Unmarshal(to, &toData); err != nil {
+			return nil, fmt.Errorf("%v; could not decode the new document", err)
+		}
+	}
+	return DiffValues(fromData, toData), nil
+}
+
+// DiffValues - the structural differences between two decoded JSON values
+func DiffValues(from, to interface{}) *objects.DiffChangeList {
+	list := &objects.DiffChangeList{Changes: []objects.DiffChange{}}
+	diffValues("", from, to, list)
+	return list
+}
+
+func diffValues(path string, from, to interface{}, list *objects.DiffChangeList) {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			diffMaps(path, f, t, list)
+			return
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			diffSlices(path, f, t, list)
+			return
+		}
+	}
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+	switch {
+	case from == nil:
+		list.Changes = append(list.Changes, objects.DiffChange{Path: rootPath(path), Op: objects.DiffAdded, New: to})
+	case to == nil:
+		list.Changes = append(list.Changes, objects.DiffChange{Path: rootPath(path), Op: objects.DiffRemoved, Old: from})
+	default:
+		list.Changes = append(list.Changes, objects.DiffChange{Path: rootPath(path), Op: objects.DiffModified, Old: from, New: to})
+	}
+}
+
+func diffMaps(path string, from, to map[string]interface{}, list *objects.DiffChangeList) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fv, inFrom := from[k]
+		tv, inTo := to[k]
+		child := keyPath(path, k)
+		switch {
+		case !inFrom:
+			list.Changes = append(list.Changes, objects.DiffChange{Path: child, Op: objects.DiffAdded, New: tv})
+		case !inTo:
+			list.Changes = append(list.Changes, objects.DiffChange{Path: child, Op: objects.DiffRemoved, Old: fv})
+		default:
+			diffValues(child, fv, tv, list)
+		}
+	}
+}
+
+func diffSlices(path string, from, to []interface{}, list *objects.DiffChangeList) {
+	for i := 0; i < len(from) || i < len(to); i++ {
+		child := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(from):
+			list.Changes = append(list.Changes, objects.DiffChange{Path: child, Op: objects.DiffAdded, New: to[i]})
+		case i >= len(to):
+			list.Changes = append(list.Changes, objects.DiffChange{Path: child, Op: objects.DiffRemoved, Old: from[i]})
+		default:
+			diffValues(child, from[i], to[i], list)
+		}
+	}
+}
+
+// keyPath - JSONPath of key k under path, keys that are not plain
+// identifiers are quoted, ie: .data["dotted.key"]
+func keyPath(path, k string) string {
+	if plainKey.MatchString(k) {
+		return fmt.Sprintf("%s.%s", path, k)
+	}
+	return fmt.Sprintf("%s[%q]", path, k)
+}
+
+func rootPath(path string) string {
+	if len(path) == 0 {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+)
+
+func TestDiffJSON(t *testing.T) {
+	from := `{"data":{"b":1,"a":{"x":true},"list":[1,2,3],"gone":"yes","dotted.key":1}}`
+	to := `{"data":{"a":{"x":false},"b":1,"list":[1,5],"new":{"k":"v"},"dotted.key":2}}`
+
+	changes, err := DiffJSON([]byte(from), []byte(to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []objects.DiffChange{
+		{Path: ".data.a.x", Op: objects.DiffModified, Old: true, New: false},
+		{Path: `.data["dotted.key"]`, Op: objects.DiffModified, Old: 1.0, New: 2.0},
+		{Path: ".data.gone", Op: objects.DiffRemoved, Old: "yes"},
+		{Path: ".data.list[1]", Op: objects.DiffModified, Old: 2.0, New: 5.0},
+		{Path: ".data.list[2]", Op: objects.DiffRemoved, Old: 3.0},
+		{Path: ".data.new", Op: objects.DiffAdded, New: map[string]interface{}{"k": "v"}},
+	}
+	if len(changes.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %+v", len(expected), changes.Changes)
+	}
+	for i, ch := range changes.Changes {
+		if ch.Path != expected[i].Path || ch.Op != expected[i].Op {
+			t.Errorf("change %d: expected %s %s, got %s %s", i, expected[i].Op, expected[i].Path, ch.Op, ch.Path)
+		}
+	}
+}
+
+func TestDiffJSONKeyOrder(t *testing.T) {
+	changes, err := DiffJSON([]byte(`{"a":1,"b":{"c":2,"d":3}}`), []byte(`{"b":{"d":3,"c":2},"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes.HasChanges() {
+		t.Fatalf("expected no changes, got %+v", changes.Changes)
+	}
+}
+
+func TestDiffJSONEmptyCurrent(t *testing.T) {
+	changes, err := DiffJSON(nil, []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes.Changes) != 1 || changes.Changes[0].Op != objects.DiffAdded || changes.Changes[0].Path != "." {
+		t.Fatalf("expected the whole document to be added, got %+v", changes.Changes)
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Editor - the editor command line from $SPLICECTL_EDITOR, $EDITOR or
+// $VISUAL, falling back to vi, or notepad on Windows
+func Editor() []string {
+	for _, env := range []string{"SPLICECTL_EDITOR", "EDITOR", "VISUAL"} {
+		if args := strings.Fields(os.Getenv(env)); len(args) > 0 {
+			return args
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// EditFile - opens path in the user's editor and waits for it to exit
+func EditFile(path string) error {
+	args := append(Editor(), path)
+	editor := exec.Command(args[0], args[1:]...)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	if err := editor.Run(); err != nil {
+		return fmt.Errorf("%v; the editor '%s' did not exit cleanly", err, strings.Join(args[:len(args)-1], " "))
+	}
+	return nil
+}
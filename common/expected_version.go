@@ -0,0 +1,31 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// VersionCheck - the vault version an apply expects to replace
+type VersionCheck struct {
+	// Set - whether --expected-version was given, version 0 means the
+	// resource must not exist yet
+	Set          bool
+	Expected     int
+	ShowConflict bool
+}
+
+// AddExpectedVersionFlags - add --expected-version and --show-conflict to cmd
+func AddExpectedVersionFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("expected-version", 0, "Only apply when the latest version in Vault is this one, see 'versions'")
+	cmd.Flags().Bool("show-conflict", false, "When --expected-version does not match, print the changes made on both sides since that version")
+}
+
+// ExpectedVersionFlags - the values of the flags added by AddExpectedVersionFlags
+func ExpectedVersionFlags(cmd *cobra.Command) VersionCheck {
+	expected, _ := cmd.Flags().GetInt("expected-version")
+	showConflict, _ := cmd.Flags().GetBool("show-conflict")
+	return VersionCheck{
+		Set:          cmd.Flags().Changed("expected-version"),
+		Expected:     expected,
+		ShowConflict: showConflict,
+	}
+}
@@ -1,16 +1,16 @@
 package common
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
-	"sort"
-	"strconv"
+	"path/filepath"
+	"strings"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	"github.com/splicemachine/splicectl/cmd/objects"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -37,47 +37,6 @@ func WantJSON(raw []byte) ([]byte, error) {
 
 }
 
-// RestructureVersions - Vault Version JSON is not well, needs some help.
-func RestructureVersions(in string) (objects.VaultVersionList, error) {
-	// The raw data out of Hashicorp Vault for versions uses JSON keys that
-	// are numeric (Version), rather than "version": <versionnum>, so we
-	// strip out the first level map[string] and re-build the struct with
-	// a Version field and populate it with the retured key.  Messy and only
-	// needed when we want to display in other formats.
-	rawData := map[string]interface{}{}
-	marshErr := json.Unmarshal([]byte(in), &rawData)
-	if marshErr != nil {
-		return objects.VaultVersionList{}, marshErr
-	}
-	var versionList []objects.VaultVersion
-	for k, v := range rawData {
-		var crvData objects.VaultVersion
-		crJSON, enverr := json.MarshalIndent(v, "", "  ")
-		if enverr != nil {
-			return objects.VaultVersionList{}, enverr
-		}
-		marshErr := json.Unmarshal([]byte(crJSON), &crvData)
-		if marshErr != nil {
-			return objects.VaultVersionList{}, marshErr
-		}
-		i, cnverr := strconv.ParseInt(k, 10, 0)
-		if cnverr != nil {
-			return objects.VaultVersionList{}, marshErr
-		}
-		crvData.Version = int(i)
-		versionList = append(versionList, crvData)
-	}
-	sort.Slice(versionList, func(i, j int) bool {
-		return versionList[i].Version < versionList[j].Version
-	})
-
-	crData := objects.VaultVersionList{
-		Versions: versionList,
-	}
-
-	return crData, nil
-}
-
 // DatabaseName - gets the most preferred database name from the command flags.
 // This is meant to be used to pick the best option when multiple flags are
 // provided for the database-name through its different aliases. A warning is
@@ -113,16 +72,14 @@ func RestConfig() (*rest.Config, error) {
 	// We aren't likely to run this INSIDE the K8s cluster, this routine
 	// simply picks up the config from the file system of a running POD.
 	// kubeCfg, err := rest.InClusterConfig()
-	var kubeCfg *rest.Config
-	var err error
-
-	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if len(Target.KubeConfig) > 0 {
+		kubeFile, err := homedir.Expand(Target.KubeConfig)
 		if err != nil {
-			logrus.Info("No KUBECONFIG ENV")
 			return nil, err
 		}
-	} else {
+		rules.ExplicitPath = kubeFile
+	} else if os.Getenv("KUBECONFIG") == "" {
 		// ENV KUBECONFIG not set, check for ~/.kube/config
 		home, err := homedir.Dir()
 		if err != nil {
@@ -138,9 +95,46 @@ func RestConfig() (*rest.Config, error) {
 				return nil, nil
 			}
 		}
-		kubeCfg, err = clientcmd.BuildConfigFromFlags("", kubeFile)
 	}
-	return kubeCfg, nil
+
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: Target.KubeContext}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+}
+
+// KubeClusterKey - identifies the cluster Target points at by the
+// kubeconfig files, the context and the server URL of its cluster, so what
+// is cached under it is not shared by kubeconfigs that reuse a context
+// name.  Empty when the cluster can't be resolved.
+func KubeClusterKey() string {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if len(Target.KubeConfig) > 0 {
+		kubeFile, err := homedir.Expand(Target.KubeConfig)
+		if err != nil {
+			return ""
+		}
+		if rules.ExplicitPath, err = filepath.Abs(kubeFile); err != nil {
+			return ""
+		}
+	}
+	raw, err := rules.Load()
+	if err != nil {
+		return ""
+	}
+	name := Target.KubeContext
+	if len(name) == 0 {
+		name = raw.CurrentContext
+	}
+	kubeContext, ok := raw.Contexts[name]
+	if !ok {
+		return ""
+	}
+	cluster, ok := raw.Clusters[kubeContext.Cluster]
+	if !ok || len(cluster.Server) == 0 {
+		return ""
+	}
+	parts := append(rules.GetLoadingPrecedence(), name, cluster.Server)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return fmt.Sprintf("%s-%x", name, sum[:8])
 }
 
 // KubeClient - gets a new kube client by reading from kube config.
@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -59,3 +61,48 @@ func testDatabaseNameWithArray(t *testing.T, args []string) {
 	tcmd.SetArgs(args)
 	tcmd.Execute()
 }
+
+func TestKubeClusterKey(t *testing.T) {
+	dir := t.TempDir()
+	kubeconfig := func(name, server string) string {
+		path := filepath.Join(dir, name)
+		data := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: kubernetes-admin@kubernetes
+contexts:
+- name: kubernetes-admin@kubernetes
+  context: {cluster: kubernetes, user: kubernetes-admin}
+clusters:
+- name: kubernetes
+  cluster: {server: %s}
+users:
+- name: kubernetes-admin
+  user: {}
+`, server)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	first := kubeconfig("first", "https://10.0.0.1:6443")
+	second := kubeconfig("second", "https://10.0.0.2:6443")
+
+	saved := Target
+	defer func() { Target = saved }()
+	keyOf := func(path string) string {
+		Target.KubeConfig = path
+		Target.KubeContext = ""
+		return KubeClusterKey()
+	}
+	a, b := keyOf(first), keyOf(second)
+	if len(a) == 0 || a == b {
+		t.Errorf("kubeconfigs with the same context name share the key %q and %q", a, b)
+	}
+	if again := keyOf(first); again != a {
+		t.Errorf("the key of a kubeconfig changed from %q to %q", a, again)
+	}
+	Target.KubeContext = "missing"
+	if key := KubeClusterKey(); len(key) != 0 {
+		t.Errorf("an unknown context should have no key, got %q", key)
+	}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ManifestDocument - one YAML or JSON document read for 'apply -f', as JSON
+type ManifestDocument struct {
+	Source string
+	JSON   []byte
+}
+
+// ReadManifests - the documents in each of paths, a directory contributes
+// its .yaml, .yml and .json files in name order (not recursively) and a
+// YAML file may hold several documents separated by '---'.
+func ReadManifests(paths []string) ([]ManifestDocument, error) {
+	docs := []ManifestDocument{}
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			raw, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			for i, part := range splitDocuments(raw) {
+				jsonBytes, err := WantJSON(part)
+				if err != nil {
+					return nil, fmt.Errorf("%v; %s document %d is not YAML or JSON", err, file, i+1)
+				}
+				docs = append(docs, ManifestDocument{Source: file, JSON: jsonBytes})
+			}
+		}
+	}
+	return docs, nil
+}
+
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// splitDocuments - the non-empty documents of a multi document YAML stream
+func splitDocuments(raw []byte) [][]byte {
+	parts := [][]byte{}
+	current := new(bytes.Buffer)
+	flush := func() {
+		if len(bytes.TrimSpace(current.Bytes())) > 0 {
+			parts = append(parts, append([]byte{}, current.Bytes()...))
+		}
+		current.Reset()
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 64*1024), len(raw)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimRight(line, " \t") == "---" {
+			flush()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+	}
+	flush()
+	return parts
+}
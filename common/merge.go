@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// absent - marks a key that is missing on one side of a merge, so a removed
+// key can be told apart from a key set to null
+var absent = &struct{}{}
+
+// MergeJSON - three-way merge of JSON documents, mine and theirs are both
+// edits of base.  Changes made on only one side are kept, a path changed on
+// both sides to different values is a conflict and keeps the value from
+// mine.  The conflicting paths are returned in the same form DiffJSON uses.
+func MergeJSON(base, mine, theirs []byte) ([]byte, []string, error) {
+	var baseData, mineData, theirData interface{}
+	for _, doc := range []struct {
+		raw  []byte
+		into *interface{}
+		name string
+	}{{base, &baseData, "original"}, {mine, &mineData, "edited"}, {theirs, &theirData, "current"}} {
+		if len(doc.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(doc.raw, doc.into); err != nil {
+			return nil, nil, fmt.Errorf("%v; could not decode the %s document", err, doc.name)
+		}
+	}
+
+	merged, conflicts := MergeValues(baseData, mineData, theirData)
+	raw, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, conflicts, nil
+}
+
+// MergeValues - three-way merge of decoded JSON values, see MergeJSON
+func MergeValues(base, mine, theirs interface{}) (interface{}, []string) {
+	conflicts := []string{}
+	merged := mergeValues("", base, mine, theirs, &conflicts)
+	return merged, conflicts
+}
+
+func mergeValues(path string, base, mine, theirs interface{}, conflicts *[]string) interface{} {
+	switch {
+	case reflect.DeepEqual(mine, theirs):
+		return mine
+	case reflect.DeepEqual(base, mine):
+		return theirs
+	case reflect.DeepEqual(base, theirs):
+		return mine
+	}
+
+	b, bok := base.(map[string]interface{})
+	m, mok := mine.(map[string]interface{})
+	t, tok := theirs.(map[string]interface{})
+	if mok && tok {
+		if !bok {
+			b = map[string]interface{}{}
+		}
+		return mergeMaps(path, b, m, t, conflicts)
+	}
+
+	*conflicts = append(*conflicts, rootPath(path))
+	return mine
+}
+
+func mergeMaps(path string, base, mine, theirs map[string]interface{}, conflicts *[]string) map[string]interface{} {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, side := range []map[string]interface{}{base, mine, theirs} {
+		for k := range side {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	merged := make(map[string]interface{}, len(keys))
+	for _, k := range keys {
+		v := mergeValues(keyPath(path, k), lookup(base, k), lookup(mine, k), lookup(theirs, k), conflicts)
+		if v != absent {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func lookup(m map[string]interface{}, k string) interface{} {
+	if v, ok := m[k]; ok {
+		return v
+	}
+	return absent
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeJSON(t *testing.T) {
+	base := `{"data":{"a":1,"b":2,"c":3,"list":[1,2]}}`
+	mine := `{"data":{"a":10,"b":2,"list":[1,2],"mine":true}}`
+	theirs := `{"data":{"a":1,"b":20,"c":3,"list":[1,2,3]}}`
+
+	merged, conflicts, err := MergeJSON([]byte(base), []byte(mine), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", conflicts)
+	}
+	var got, expected interface{}
+	json.Unmarshal(merged, &got)
+	json.Unmarshal([]byte(`{"data":{"a":10,"b":20,"list":[1,2,3],"mine":true}}`), &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestMergeJSONConflict(t *testing.T) {
+	base := `{"data":{"a":1,"b":2}}`
+	mine := `{"data":{"a":10}}`
+	theirs := `{"data":{"a":11,"b":3}}`
+
+	_, conflicts, err := MergeJSON([]byte(base), []byte(mine), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(conflicts, []string{".data.a", ".data.b"}) {
+		t.Errorf("expected conflicts on .data.a and .data.b, got %v", conflicts)
+	}
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// FuzzyMatch - whether the characters of filter appear in value in the
+// same order, ignoring case and spaces, so 'sdb' matches 'splicedb'
+func FuzzyMatch(filter, value string) bool {
+	value = strings.ToLower(value)
+	for _, r := range strings.ToLower(filter) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		i := strings.IndexRune(value, r)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(string(r)):]
+	}
+	return true
+}
+
+// PromptDefaultsPath - ~/.splicectl/prompts.json, the last answer to each
+// prompt per context, offered as the default the next time
+func PromptDefaultsPath() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".splicectl", "prompts.json"), nil
+}
+
+// ReadPromptDefaults - the saved answers by key, none when the file is
+// missing or unreadable
+func ReadPromptDefaults(path string) map[string]string {
+	defaults := map[string]string{}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return defaults
+	}
+	if err := json.Unmarshal(raw, &defaults); err != nil {
+		return map[string]string{}
+	}
+	return defaults
+}
+
+// SavePromptDefault - remembers the answer to a prompt
+func SavePromptDefault(path, key, value string) error {
+	defaults := ReadPromptDefaults(path)
+	defaults[key] = value
+	raw, err := json.MarshalIndent(defaults, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0600)
+}
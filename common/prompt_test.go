@@ -0,0 +1,41 @@
+package common
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tc := range []struct {
+		filter, value string
+		want          bool
+	}{
+		{"", "splicedb", true},
+		{"sdb", "splicedb", true},
+		{"SDB", "splicedb", true},
+		{"acme 12", "12ab (Acme, Inc <x@y>)", false},
+		{"12 acme", "12ab (Acme, Inc <x@y>)", true},
+		{"dbs", "splicedb", false},
+	} {
+		if got := FuzzyMatch(tc.filter, tc.value); got != tc.want {
+			t.Errorf("FuzzyMatch(%q, %q) = %t, expected %t", tc.filter, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestPromptDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompts.json")
+	if got := ReadPromptDefaults(path); len(got) != 0 {
+		t.Errorf("ReadPromptDefaults() of a missing file = %v", got)
+	}
+	for _, answer := range []string{"db1", "db2"} {
+		if err := SavePromptDefault(path, "dev/database-name", answer); err != nil {
+			t.Fatalf("SavePromptDefault() error = %v", err)
+		}
+	}
+	SavePromptDefault(path, "dev/cloud-provider", "AWS")
+	got := ReadPromptDefaults(path)
+	if got["dev/database-name"] != "db2" || got["dev/cloud-provider"] != "AWS" {
+		t.Errorf("ReadPromptDefaults() = %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+)
+
+// sensitiveKey - the keys whose values are never shared, the last applied
+// configuration annotation repeats the whole object and is dropped as well
+var sensitiveKey = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private.?key|access.?key|api.?key|auth|cert|last-applied-configuration)`)
+
+// sensitiveSetting - a setting with a sensitive name in a line of text, as
+// name=value, name: value or "name": "value", and bearer tokens
+var sensitiveSetting = regexp.MustCompile(`(?i)((?:password|passwd|secret|token|credential|private.?key|access.?key|api.?key)[\w.-]*["']?[ \t]*[:=][ \t]*["']?|bearer[ \t]+)[^\s"',;&]+`)
+
+// Redact - v as generic JSON values with the value of every sensitive key
+// replaced by Redacted.  Name/value pairs, like the environment of a
+// container, are redacted by their name.  Only scalar values are replaced,
+// so references such as secretKeyRef are kept.
+func Redact(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return redactValue(generic), nil
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if name, ok := value["name"].(string); ok && sensitiveKey.MatchString(name) {
+			if _, scalar := value["value"].(string); scalar {
+				value["value"] = Redacted
+			}
+		}
+		for key, inner := range value {
+			if sensitiveKey.MatchString(key) && isScalar(inner) {
+				value[key] = Redacted
+				continue
+			}
+			value[key] = redactValue(inner)
+		}
+		return value
+	case []interface{}:
+		for idx, inner := range value {
+			value[idx] = redactValue(inner)
+		}
+		return value
+	}
+	return v
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, nil:
+		return false
+	}
+	return true
+}
+
+// RedactLine - line with the value of every sensitive setting replaced by
+// Redacted, other values it prints are kept
+func RedactLine(line string) string {
+	return sensitiveSetting.ReplaceAllString(line, "${1}"+Redacted)
+}
+
+// RedactWriter - redacts what is written to it line by line, see RedactLine
+type RedactWriter struct {
+	w       io.WriteCloser
+	partial []byte
+}
+
+// NewRedactWriter - a writer that writes the redacted lines to w
+func NewRedactWriter(w io.WriteCloser) *RedactWriter {
+	return &RedactWriter{w: w}
+}
+
+func (rw *RedactWriter) Write(p []byte) (int, error) {
+	rw.partial = append(rw.partial, p...)
+	end := bytes.LastIndexByte(rw.partial, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(rw.w, RedactLine(string(rw.partial[:end+1]))); err != nil {
+		return 0, err
+	}
+	rw.partial = append(rw.partial[:0], rw.partial[end+1:]...)
+	return len(p), nil
+}
+
+// Close - writes the last line when it does not end with a newline
+func (rw *RedactWriter) Close() error {
+	var err error
+	if len(rw.partial) > 0 {
+		_, err = io.WriteString(rw.w, RedactLine(string(rw.partial)))
+	}
+	if cerr := rw.w.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
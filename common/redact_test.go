@@ -0,0 +1,72 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	in := map[string]interface{}{
+		"replicas": 3,
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": `{"spec":{}}`,
+				"owner": "dba",
+			},
+		},
+		"env": []interface{}{
+			map[string]interface{}{"name": "DB_PASSWORD", "value": "hunter2"},
+			map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+			map[string]interface{}{"name": "API_TOKEN", "valueFrom": map[string]interface{}{"secretKeyRef": map[string]interface{}{"name": "db"}}},
+		},
+		"awsSecretAccessKey": "abc",
+	}
+	got, err := Redact(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"replicas": float64(3),
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": Redacted,
+				"owner": "dba",
+			},
+		},
+		"env": []interface{}{
+			map[string]interface{}{"name": "DB_PASSWORD", "value": Redacted},
+			map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+			map[string]interface{}{"name": "API_TOKEN", "valueFrom": map[string]interface{}{"secretKeyRef": map[string]interface{}{"name": "db"}}},
+		},
+		"awsSecretAccessKey": Redacted,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRedactWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewRedactWriter(nopCloser{&out})
+	io.WriteString(w, "start password=hunter2 user=splice\n\"apiKey\": \"abc\", \"level\": \"info\"\nAuthorization: Bearer eyJhbG")
+	io.WriteString(w, "ciOi.x\n2021-06-01 token: t0k3n\nno newline, secret=s")
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "start password=REDACTED user=splice\n" +
+		"\"apiKey\": \"REDACTED\", \"level\": \"info\"\n" +
+		"Authorization: Bearer REDACTED\n" +
+		"2021-06-01 token: REDACTED\n" +
+		"no newline, secret=REDACTED"
+	if out.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
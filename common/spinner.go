@@ -0,0 +1,89 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// IsTerminal - whether f is attached to a terminal
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// Spinner - shows progress of a long running operation.  On a terminal the
+// message and status are redrawn in place, otherwise each status change is
+// written as a line of its own so CI logs stay readable.
+type Spinner struct {
+	out     io.Writer
+	tty     bool
+	message string
+	status  string
+	mu      sync.Mutex
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewSpinner - starts a spinner writing to f
+func NewSpinner(f *os.File, message string) *Spinner {
+	s := &Spinner{
+		out:     f,
+		tty:     IsTerminal(f),
+		message: message,
+		done:    make(chan struct{}),
+	}
+	if s.tty {
+		s.wg.Add(1)
+		go s.spin()
+	}
+	return s
+}
+
+func (s *Spinner) spin() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(150 * time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		fmt.Fprintf(s.out, "\r\033[K%s %s %s", spinnerFrames[i%len(spinnerFrames)], s.message, s.status)
+		s.mu.Unlock()
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update - sets the status shown next to the message
+func (s *Spinner) Update(status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if status == s.status {
+		return
+	}
+	s.status = status
+	if !s.tty {
+		fmt.Fprintf(s.out, "%s %s\n", s.message, status)
+	}
+}
+
+// Stop - stops the spinner and clears its line
+func (s *Spinner) Stop() {
+	select {
+	case <-s.done:
+		return
+	default:
+		close(s.done)
+	}
+	s.wg.Wait()
+	if s.tty {
+		fmt.Fprint(s.out, "\r\033[K")
+	}
+}
@@ -5,3 +5,25 @@ type SessionData struct {
 	SessionID  string `json:"session_id"`
 	ValidUntil string `json:"valid_until"`
 }
+
+const (
+	// DefaultSystemNamespace - namespace holding the splicectl API and its secrets
+	DefaultSystemNamespace = "splice-system"
+	// DefaultAPIIngress - name of the ingress in front of the splicectl API
+	DefaultAPIIngress = "splicectl-api"
+)
+
+// KubeTarget - Kubernetes cluster details used to locate the splicectl API
+type KubeTarget struct {
+	KubeConfig  string
+	KubeContext string
+	Namespace   string
+	Ingress     string
+}
+
+// Target - the cluster the current invocation talks to, the values are
+// replaced by those of the active named context before any command runs.
+var Target = KubeTarget{
+	Namespace: DefaultSystemNamespace,
+	Ingress:   DefaultAPIIngress,
+}
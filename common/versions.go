@@ -0,0 +1,49 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveVersionRange - turns a range of vault versions, "3..5" or
+// "latest~1..latest", into version numbers.  latest~N is N versions before
+// the newest one.
+func ResolveVersionRange(spec string, latest int) (int, int, error) {
+	parts := strings.Split(spec, "..")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("version range '%s' is not in the form <from>..<to>", spec)
+	}
+	from, err := resolveVersion(parts[0], latest)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := resolveVersion(parts[1], latest)
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+func resolveVersion(ref string, latest int) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if strings.HasPrefix(ref, "latest") {
+		offset := 0
+		if rest := strings.TrimPrefix(ref, "latest"); len(rest) > 0 {
+			n, err := strconv.Atoi(strings.TrimPrefix(rest, "~"))
+			if !strings.HasPrefix(rest, "~") || err != nil || n < 0 {
+				return 0, fmt.Errorf("'%s' is not a valid version, use N, latest or latest~N", ref)
+			}
+			offset = n
+		}
+		if latest-offset < 1 {
+			return 0, fmt.Errorf("'%s' is before the first version", ref)
+		}
+		return latest - offset, nil
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("'%s' is not a valid version, use N, latest or latest~N", ref)
+	}
+	return n, nil
+}
@@ -0,0 +1,29 @@
+package common
+
+import "testing"
+
+func TestResolveVersionRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		from, to int
+		valid    bool
+	}{
+		{"3..5", 3, 5, true},
+		{"latest~1..latest", 6, 7, true},
+		{"1..latest~2", 1, 5, true},
+		{"latest~7..latest", 0, 0, false},
+		{"3", 0, 0, false},
+		{"a..5", 0, 0, false},
+		{"latest-1..latest", 0, 0, false},
+	}
+	for _, tt := range tests {
+		from, to, err := ResolveVersionRange(tt.spec, 7)
+		if (err == nil) != tt.valid {
+			t.Errorf("%s: unexpected error %v", tt.spec, err)
+			continue
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("%s: expected %d..%d, got %d..%d", tt.spec, tt.from, tt.to, from, to)
+		}
+	}
+}
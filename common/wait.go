@@ -0,0 +1,33 @@
+package common
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// WaitOptions - how long, and how often, to poll for a workspace to reach
+// the state a command asked for
+type WaitOptions struct {
+	Wait         bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// AddWaitFlags - add --wait, --timeout and --poll-interval to cmd
+func AddWaitFlags(cmd *cobra.Command, timeout time.Duration) {
+	cmd.Flags().Bool("wait", false, "Wait for the workspace to reach the requested state")
+	cmd.Flags().Duration("timeout", timeout, "How long --wait waits before giving up")
+	cmd.Flags().Duration("poll-interval", 10*time.Second, "How often --wait checks the workspace status")
+}
+
+// WaitFlags - the values of the flags added by AddWaitFlags
+func WaitFlags(cmd *cobra.Command) WaitOptions {
+	wait, _ := cmd.Flags().GetBool("wait")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	interval, _ := cmd.Flags().GetDuration("poll-interval")
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return WaitOptions{Wait: wait, Timeout: timeout, PollInterval: interval}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/printers"
+)
+
+// AddWatchFlags - add --watch/-w and --watch-interval to cmd
+func AddWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("watch", "w", false, "Keep polling and show the changes, redrawn in place on a terminal or as JSON lines when piped")
+	cmd.Flags().Duration("watch-interval", 5*time.Second, "How often --watch polls")
+}
+
+// WatchFlags - whether --watch was given, and the poll interval
+func WatchFlags(cmd *cobra.Command) (bool, time.Duration) {
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("watch-interval")
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return watch, interval
+}
+
+// WatchKeyer - output whose rows are not identified by their first cell,
+// WatchKeys has the key of each row in the order of the rows
+type WatchKeyer interface {
+	WatchKeys() []string
+}
+
+// WatchTable - the table of a poll of v, keyed by its WatchKeys when it
+// is a WatchKeyer
+func WatchTable(v interface{}, opts printers.Options) (*printers.Table, error) {
+	table, err := printers.TableFor(v, opts)
+	if err != nil {
+		return nil, err
+	}
+	if keyer, ok := v.(WatchKeyer); ok {
+		if keys := keyer.WatchKeys(); len(keys) == len(table.Rows) {
+			table.Keys = keys
+		}
+	}
+	return table, nil
+}
+
+// TableKeys - the key of each row of a table, its Keys or else its first
+// cell, rows with the same key are numbered
+func TableKeys(table *printers.Table) []string {
+	keys := make([]string, 0, len(table.Rows))
+	seen := map[string]int{}
+	for i, row := range table.Rows {
+		key := ""
+		switch {
+		case len(table.Keys) == len(table.Rows):
+			key = table.Keys[i]
+		case len(row) > 0:
+			key = row[0]
+		}
+		if n := seen[key]; n > 0 {
+			seen[key]++
+			key = fmt.Sprintf("%s#%d", key, n)
+		} else {
+			seen[key] = 1
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// DiffTables - the rows added, changed or removed between two polls of a
+// watched table, prev is nil on the first poll
+func DiffTables(prev, next *printers.Table, now time.Time) []objects.WatchEvent {
+	events := make([]objects.WatchEvent, 0)
+	prevRows := map[string][]string{}
+	if prev != nil {
+		for i, key := range TableKeys(prev) {
+			prevRows[key] = prev.Rows[i]
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, key := range TableKeys(next) {
+		seen[key] = true
+		row := next.Rows[i]
+		old, ok := prevRows[key]
+		if !ok {
+			events = append(events, objects.WatchEvent{Time: now, Type: objects.WatchAdded, Key: key, Row: rowMap(next.Header, row)})
+			continue
+		}
+		changes := map[string]objects.WatchChange{}
+		for j, cell := range row {
+			if j < len(old) && old[j] != cell && j < len(next.Header) {
+				changes[next.Header[j]] = objects.WatchChange{Old: old[j], New: cell}
+			}
+		}
+		if len(changes) > 0 {
+			events = append(events, objects.WatchEvent{Time: now, Type: objects.WatchModified, Key: key, Row: rowMap(next.Header, row), Changes: changes})
+		}
+	}
+	if prev != nil {
+		for i, key := range TableKeys(prev) {
+			if !seen[key] {
+				events = append(events, objects.WatchEvent{Time: now, Type: objects.WatchDeleted, Key: key, Row: rowMap(prev.Header, prev.Rows[i])})
+			}
+		}
+	}
+	return events
+}
+
+func rowMap(header, row []string) map[string]string {
+	m := make(map[string]string, len(row))
+	for i, cell := range row {
+		if i < len(header) {
+			m[header[i]] = cell
+		}
+	}
+	return m
+}
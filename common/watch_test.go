@@ -0,0 +1,53 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/splicemachine/splicectl/cmd/objects"
+	"github.com/splicemachine/splicectl/printers"
+)
+
+func TestDiffTables(t *testing.T) {
+	now := time.Now()
+	header := []string{"DATABASE", "STATUS"}
+	prev := &printers.Table{Header: header, Rows: [][]string{{"db1", "ACTIVE"}, {"db2", "ACTIVE"}, {"db3", "PAUSED"}}}
+	next := &printers.Table{Header: header, Rows: [][]string{{"db1", "ACTIVE"}, {"db2", "PAUSED"}, {"db4", "CREATING"}}}
+
+	if events := DiffTables(nil, prev, now); len(events) != 3 || events[0].Type != objects.WatchAdded {
+		t.Errorf("first poll = %v, want every row added", events)
+	}
+
+	events := DiffTables(prev, next, now)
+	got := make([]string, 0)
+	for _, e := range events {
+		got = append(got, e.Type+" "+e.Key)
+	}
+	want := []string{"MODIFIED db2", "ADDED db4", "DELETED db3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiffTables() = %v, want %v", got, want)
+	}
+	change := events[0].Changes["STATUS"]
+	if change.Old != "ACTIVE" || change.New != "PAUSED" || events[0].Row["DATABASE"] != "db2" {
+		t.Errorf("MODIFIED event = %+v", events[0])
+	}
+
+	if events := DiffTables(next, next, now); len(events) != 0 {
+		t.Errorf("unchanged poll = %v, want no events", events)
+	}
+}
+
+func TestTableKeys(t *testing.T) {
+	table := &printers.Table{Rows: [][]string{{"a"}, {"b"}, {"a"}, {}}}
+	want := []string{"a", "b", "a#1", ""}
+	if got := TableKeys(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("TableKeys() = %v, want %v", got, want)
+	}
+
+	table.Keys = []string{"k1", "k2", "k1", "k3"}
+	want = []string{"k1", "k2", "k1#1", "k3"}
+	if got := TableKeys(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("TableKeys() with Keys = %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,7 @@ go 1.16
 require (
 	github.com/AlecAivazis/survey/v2 v2.1.1
 	github.com/blang/semver/v4 v4.0.0
+	github.com/fatih/color v1.10.0
 	github.com/go-resty/resty/v2 v2.2.0
 	github.com/imdario/mergo v0.3.9 // indirect
 	github.com/maahsome/gron v0.1.0
@@ -15,6 +16,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.6.3
 	github.com/stretchr/testify v1.6.1
+	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d
 	gopkg.in/yaml.v2 v2.4.0
 	k8s.io/api v0.21.2
 	k8s.io/apimachinery v0.21.2
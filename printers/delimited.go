@@ -0,0 +1,92 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// tableRows - the header, unless noHeaders, followed by the rows of the
+// table of v
+func tableRows(v interface{}, opts Options) (*Table, [][]string, error) {
+	table, err := TableFor(v, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the output has no table: %w", err)
+	}
+	rows := make([][]string, 0, len(table.Rows)+1)
+	if !opts.NoHeaders {
+		rows = append(rows, table.Header)
+	}
+	return table, append(rows, table.Rows...), nil
+}
+
+// printCSV - the table as RFC 4180 CSV
+func printCSV(v interface{}, opts Options) (string, error) {
+	_, rows, err := tableRows(v, opts)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+	if err := w.WriteAll(rows); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// tsvEscaper - tabs, newlines and backslashes in a TSV cell are written
+// as escape sequences
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// printTSV - the table as tab separated values
+func printTSV(v interface{}, opts Options) (string, error) {
+	_, rows, err := tableRows(v, opts)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	for _, row := range rows {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, tsvEscaper.Replace(cell))
+		}
+		fmt.Fprintln(buf, strings.Join(cells, "\t"))
+	}
+	return buf.String(), nil
+}
+
+// markdownEscaper - pipes would end the cell and newlines the row
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// printMarkdown - the table as a GitHub flavored markdown table, the footer
+// follows it as a paragraph.  A markdown table needs its header and
+// separator line, so --no-headers is ignored.
+func printMarkdown(v interface{}, opts Options) (string, error) {
+	table, _, err := tableRows(v, opts)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	writeRow := func(row []string) {
+		cells := make([]string, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, markdownEscaper.Replace(cell))
+		}
+		fmt.Fprintf(buf, "| %s |\n", strings.Join(cells, " | "))
+	}
+	writeRow(table.Header)
+	separator := make([]string, 0, len(table.Header))
+	for range table.Header {
+		separator = append(separator, "---")
+	}
+	writeRow(separator)
+	for _, row := range table.Rows {
+		writeRow(row)
+	}
+	footer := strings.TrimSpace(strings.Join(table.Footer, " "))
+	if len(footer) > 0 {
+		fmt.Fprintf(buf, "\n%s\n", markdownEscaper.Replace(footer))
+	}
+	return buf.String(), nil
+}
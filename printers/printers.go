@@ -0,0 +1,264 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/maahsome/gron"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrUnknownFormat - no printer is registered for the requested format
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// Options - settings shared by every printer, Arg is the text after the
+// '=' of the format, ie: the template of jsonpath={.name}
+type Options struct {
+	NoHeaders bool
+	Wide      bool
+	Arg       string
+}
+
+// Printer - renders v in one output format
+type Printer func(v interface{}, opts Options) (string, error)
+
+// Texter - implemented by types whose text output is not a table, ie: a diff
+type Texter interface {
+	ToText(noHeaders bool) string
+}
+
+// Tabler - implemented by types whose table can not be described with
+// struct tags, ie: a column computed from more than one field
+type Tabler interface {
+	Table(opts Options) *Table
+}
+
+// Cell - implemented by field types that choose their own text in a table
+// cell, ie: a summary of a slice
+type Cell interface {
+	Cell() string
+}
+
+// Redactor - implemented by types holding values that must never be
+// displayed, the structured printers render the redacted copy
+type Redactor interface {
+	Redacted() interface{}
+}
+
+// entry - a registered printer, check validates the argument of formats
+// that take one
+type entry struct {
+	print Printer
+	check func(arg string) error
+}
+
+var (
+	registry   = map[string]entry{}
+	registryMu sync.RWMutex
+)
+
+func init() {
+	Register("json", printJSON)
+	Register("raw", printRaw)
+	Register("yaml", printYAML)
+	Register("gron", printGRON)
+	Register("text", printText)
+	Register("table", printText)
+	Register("wide", printWide)
+	Register("csv", printCSV)
+	Register("tsv", printTSV)
+	Register("markdown", printMarkdown)
+	RegisterWithArg("jsonpath", printJSONPath, checkJSONPath)
+	RegisterWithArg("go-template", printGoTemplate, checkGoTemplate)
+	RegisterWithArg("go-template-file", printGoTemplateFile, checkGoTemplateFile)
+	RegisterWithArg("custom-columns", printCustomColumns, checkCustomColumns)
+}
+
+// Register - makes a printer available for '-o <format>', registering the
+// same format twice replaces the printer
+func Register(format string, p Printer) {
+	RegisterWithArg(format, p, nil)
+}
+
+// RegisterWithArg - registers a format that is given an argument after an
+// '=', check validates the argument before anything is run
+func RegisterWithArg(format string, p Printer, check func(arg string) error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(format)] = entry{print: p, check: check}
+}
+
+// splitFormat - the name of the format and its argument
+func splitFormat(format string) (string, string) {
+	parts := strings.SplitN(format, "=", 2)
+	if len(parts) == 1 {
+		return strings.ToLower(parts[0]), ""
+	}
+	return strings.ToLower(parts[0]), parts[1]
+}
+
+// Lookup - the printer for a format, format may carry an argument after an
+// '=', ie: jsonpath={.name}
+func Lookup(format string) (Printer, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	name, _ := splitFormat(format)
+	e, ok := registry[name]
+	return e.print, ok
+}
+
+// Validate - checks the format is registered and that its argument, if it
+// takes one, is valid
+func Validate(format string) error {
+	registryMu.RLock()
+	name, arg := splitFormat(format)
+	e, ok := registry[name]
+	registryMu.RUnlock()
+	switch {
+	case !ok:
+		return fmt.Errorf("%w '%s', valid formats are [%s]", ErrUnknownFormat, format, strings.Join(Formats(), "|"))
+	case e.check != nil:
+		if err := e.check(arg); err != nil {
+			return fmt.Errorf("invalid -o %s: %w", name, err)
+		}
+	case len(arg) > 0:
+		return fmt.Errorf("-o %s does not take an argument", name)
+	}
+	return nil
+}
+
+// Formats - the registered formats, sorted, formats that take an argument
+// are shown as name=...
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	formats := make([]string, 0, len(registry))
+	for k, e := range registry {
+		if e.check != nil {
+			k += "=..."
+		}
+		formats = append(formats, k)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Print - renders v in the given format
+func Print(format string, v interface{}, opts Options) (string, error) {
+	p, ok := Lookup(format)
+	if !ok {
+		return "", fmt.Errorf("%w '%s', valid formats are [%s]", ErrUnknownFormat, format, strings.Join(Formats(), "|"))
+	}
+	_, opts.Arg = splitFormat(format)
+	return p(v, opts)
+}
+
+// ToJSON - v as indented JSON, logging and returning "" on error
+func ToJSON(v interface{}) string {
+	out, err := printJSON(v, Options{})
+	if err != nil {
+		logrus.WithError(err).Error("Error extracting json")
+	}
+	return out
+}
+
+// ToYAML - v as YAML, logging and returning "" on error
+func ToYAML(v interface{}) string {
+	out, err := printYAML(v, Options{})
+	if err != nil {
+		logrus.WithError(err).Error("Error extracting yaml")
+	}
+	return out
+}
+
+// structured - the value the structured printers render
+func structured(v interface{}) interface{} {
+	if r, ok := v.(Redactor); ok {
+		return r.Redacted()
+	}
+	return v
+}
+
+// generic - v as the maps and slices decoded from its JSON, so templates
+// use the JSON field names
+func generic(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(structured(v))
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func printJSON(v interface{}, opts Options) (string, error) {
+	out, err := json.MarshalIndent(structured(v), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func printRaw(v interface{}, opts Options) (string, error) {
+	out, err := json.Marshal(structured(v))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func printYAML(v interface{}, opts Options) (string, error) {
+	out, err := yaml.Marshal(structured(v))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func printGRON(v interface{}, opts Options) (string, error) {
+	out, err := json.MarshalIndent(structured(v), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	values := &bytes.Buffer{}
+	ges := gron.NewGron(bytes.NewReader(out), values)
+	ges.SetMonochrome(false)
+	if err := ges.ToGron(); err != nil {
+		return "", fmt.Errorf("problem generating gron syntax: %w", err)
+	}
+	return values.String(), nil
+}
+
+// printText - Texter output, else the table of v, types that declare no
+// columns are written as YAML
+func printText(v interface{}, opts Options) (string, error) {
+	if t, ok := v.(Texter); ok {
+		return t.ToText(opts.NoHeaders), nil
+	}
+	table, err := TableFor(v, opts)
+	if errors.Is(err, ErrNoColumns) {
+		return printYAML(v, opts)
+	}
+	if err != nil {
+		return "", err
+	}
+	return table.Render(opts.NoHeaders), nil
+}
+
+// printWide - the text output with the wide columns, output without wide
+// columns is rejected rather than shown as plain text
+func printWide(v interface{}, opts Options) (string, error) {
+	if !hasWideColumns(v, opts) {
+		return "", ErrNoWideColumns
+	}
+	opts.Wide = true
+	return printText(v, opts)
+}
@@ -0,0 +1,190 @@
+package printers
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testOwner struct {
+	Email string `table:"OWNER"`
+}
+
+type testRow struct {
+	Name    string     `table:"NAME,order=1"`
+	ID      string     `table:"ID,order=2"`
+	Tags    []string   `table:"TAGS,order=3"`
+	Node    string     `table:"NODE,wide,order=4"`
+	Result  string     `table:"RESULT,omitempty,order=5"`
+	Created time.Time  `table:"CREATED,order=6"`
+	Owner   *testOwner `table:",inline"`
+	Secret  string     `json:"secret"`
+}
+
+type testList struct {
+	Items []testRow `table:",rows"`
+}
+
+type testSecret struct {
+	Token string `json:"token"`
+}
+
+func (s *testSecret) Redacted() interface{} {
+	return &testSecret{Token: "****"}
+}
+
+func TestTableOf(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	list := &testList{Items: []testRow{
+		{Name: "db1", ID: "1", Tags: []string{"a", "b"}, Node: "n1", Created: created, Owner: &testOwner{Email: "x@y"}},
+		{Name: "db2", ID: "2"},
+	}}
+
+	tests := []struct {
+		name   string
+		opts   Options
+		header []string
+		row    []string
+	}{
+		{
+			name:   "default",
+			header: []string{"OWNER", "NAME", "ID", "TAGS", "CREATED"},
+			row:    []string{"x@y", "db1", "1", "a b", created.Local().Format(time.RFC3339)},
+		},
+		{
+			name:   "wide",
+			opts:   Options{Wide: true},
+			header: []string{"OWNER", "NAME", "ID", "TAGS", "NODE", "CREATED"},
+			row:    []string{"x@y", "db1", "1", "a b", "n1", created.Local().Format(time.RFC3339)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := TableOf(list, tt.opts)
+			if err != nil {
+				t.Fatalf("TableOf() error = %v", err)
+			}
+			if !reflect.DeepEqual(table.Header, tt.header) {
+				t.Errorf("Header = %v, want %v", table.Header, tt.header)
+			}
+			if len(table.Rows) != 2 {
+				t.Fatalf("got %d rows, want 2", len(table.Rows))
+			}
+			if !reflect.DeepEqual(table.Rows[0], tt.row) {
+				t.Errorf("Rows[0] = %v, want %v", table.Rows[0], tt.row)
+			}
+		})
+	}
+
+	list.Items[1].Result = "ok"
+	table, _ := TableOf(list, Options{})
+	if table.Header[len(table.Header)-2] != "RESULT" {
+		t.Errorf("omitempty column with a value was dropped: %v", table.Header)
+	}
+}
+
+func TestTableOfMap(t *testing.T) {
+	table, err := TableOf(map[string]interface{}{"b": 2, "a": "one"}, Options{})
+	if err != nil {
+		t.Fatalf("TableOf() error = %v", err)
+	}
+	want := [][]string{{"a", "one"}, {"b", "2"}}
+	if !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("Rows = %v, want %v", table.Rows, want)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	if _, err := Print("xml", &testList{}, Options{}); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Print(xml) error = %v, want ErrUnknownFormat", err)
+	}
+
+	if _, err := Print("wide", &testList{}, Options{}); err != nil {
+		t.Errorf("Print(wide) of a list with wide columns error = %v", err)
+	}
+	if _, err := Print("wide", []testOwner{{Email: "x@y"}}, Options{}); !errors.Is(err, ErrNoWideColumns) {
+		t.Errorf("Print(wide) without wide columns error = %v, want ErrNoWideColumns", err)
+	}
+
+	out, err := Print("json", &testSecret{Token: "abc"}, Options{})
+	if err != nil || strings.Contains(out, "abc") {
+		t.Errorf("Print(json) = %q, %v, want the redacted copy", out, err)
+	}
+
+	// types without columns fall back to yaml
+	out, err = Print("text", &testSecret{Token: "abc"}, Options{})
+	if err != nil || strings.TrimSpace(out) != "token: '****'" {
+		t.Errorf("Print(text) = %q, %v", out, err)
+	}
+
+	out, _ = Print("table", &testList{Items: []testRow{{Name: "db1"}}}, Options{NoHeaders: true})
+	if strings.Contains(out, "NAME") || !strings.Contains(out, "db1") {
+		t.Errorf("Print(table) with NoHeaders = %q", out)
+	}
+}
+
+func TestTemplatePrinters(t *testing.T) {
+	list := &testList{Items: []testRow{{Name: "db1", Secret: "s1"}, {Name: "db2", Secret: "s2"}}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "jsonpath={.Items[*].secret}", want: "s1 s2"},
+		{format: "jsonpath=.Items[0].Name", want: "db1"},
+		{format: "go-template={{range .Items}}{{.Name}};{{end}}", want: "db1;db2;"},
+		{format: "custom-columns=N:.Name,S:.secret,X:.missing", want: "N S X db1 s1 <none> db2 s2 <none>"},
+		{format: "custom-columns=N:.Name,BOTH:{['Name','secret']},S:.secret", want: "N BOTH S db1 db1,s1 s1 db2 db2,s2 s2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if err := Validate(tt.format); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			got, err := Print(tt.format, list, Options{})
+			if err != nil {
+				t.Fatalf("Print() error = %v", err)
+			}
+			if got = strings.Join(strings.Fields(got), " "); got != tt.want {
+				t.Errorf("Print() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for _, format := range []string{"jsonpath=", "jsonpath={.x", "custom-columns=NAME", "go-template={{.x", "json=x"} {
+		if err := Validate(format); err == nil {
+			t.Errorf("Validate(%q) want an error", format)
+		}
+	}
+}
+
+func TestDelimitedPrinters(t *testing.T) {
+	list := &testList{Items: []testRow{{Name: "a,\"b\"", ID: "x\ty|z"}, {Name: "line\nbreak"}}}
+	tests := []struct {
+		format    string
+		noHeaders bool
+		want      string
+	}{
+		{format: "csv", want: "OWNER,NAME,ID,TAGS,CREATED\n,\"a,\"\"b\"\"\",x\ty|z,,\n,\"line\nbreak\",,,\n"},
+		{format: "csv", noHeaders: true, want: ",\"a,\"\"b\"\"\",x\ty|z,,\n,\"line\nbreak\",,,\n"},
+		{format: "tsv", want: "OWNER\tNAME\tID\tTAGS\tCREATED\n\ta,\"b\"\tx\\ty|z\t\t\n\tline\\nbreak\t\t\t\n"},
+		{format: "markdown", want: "| OWNER | NAME | ID | TAGS | CREATED |\n| --- | --- | --- | --- | --- |\n|  | a,\"b\" | x\ty\\|z |  |  |\n|  | line<br>break |  |  |  |\n"},
+		{format: "markdown", noHeaders: true, want: "| OWNER | NAME | ID | TAGS | CREATED |\n| --- | --- | --- | --- | --- |\n|  | a,\"b\" | x\ty\\|z |  |  |\n|  | line<br>break |  |  |  |\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := Print(tt.format, list, Options{NoHeaders: tt.noHeaders})
+			if err != nil {
+				t.Fatalf("Print() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Print() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Print("csv", &testSecret{}, Options{}); err == nil {
+		t.Error("Print(csv) of a type without columns want an error")
+	}
+}
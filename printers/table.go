@@ -0,0 +1,348 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// ErrNoColumns - the type declares no table columns
+var ErrNoColumns = errors.New("no table columns")
+
+// ErrNoWideColumns - -o wide was asked for output that has no wide columns
+var ErrNoWideColumns = errors.New("-o wide is not supported by this command, it has no wide columns")
+
+// Table - the rows of the text output, Footer is optional.  Keys, when
+// set, has the key of each row that --watch tracks it by
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Footer []string
+	Keys   []string
+}
+
+// Append - adds a row to the table
+func (t *Table) Append(row ...string) {
+	t.Rows = append(t.Rows, row)
+}
+
+// Render - Write the table as Text
+func (t *Table) Render(noHeaders bool) string {
+	buf := new(bytes.Buffer)
+
+	// ******************** TableWriter *******************************
+	table := tablewriter.NewWriter(buf)
+	if !noHeaders {
+		table.SetHeader(t.Header)
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	}
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(true)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+	table.SetTablePadding("\t") // pad with tabs
+	table.SetNoWhiteSpace(true)
+	if len(t.Footer) > 0 {
+		table.SetFooter(t.Footer)
+	}
+	table.AppendBulk(t.Rows)
+	table.Render()
+
+	return buf.String()
+}
+
+// TableFor - the table of v, from its Table method when it is a Tabler,
+// otherwise from its struct tags
+func TableFor(v interface{}, opts Options) (*Table, error) {
+	if t, ok := v.(Tabler); ok {
+		return t.Table(opts), nil
+	}
+	return TableOf(v, opts)
+}
+
+// TableOf - the table described by the struct tags of v.  A field tagged
+//
+//	`table:"HEADER[,wide][,omitempty][,order=N]"`
+//
+// is a column, wide columns are only shown with Options.Wide and omitempty
+// columns are dropped when every cell is empty.  Columns are ordered by
+// order=N, then by declaration.  `table:",inline"` adds the columns of a
+// nested struct, and `table:",rows"` on a slice or map field makes its
+// elements the rows.  A struct without a rows field is a single row, and
+// a map is a sorted KEY/VALUE table.  Field types implementing Cell choose
+// their own text.
+func TableOf(v interface{}, opts Options) (*Table, error) {
+	return tableOf(reflect.ValueOf(v), opts)
+}
+
+type column struct {
+	header    string
+	index     []int
+	wide      bool
+	omitempty bool
+	order     int
+}
+
+func tableOf(rv reflect.Value, opts Options) (*Table, error) {
+	rv = indirect(rv)
+	if !rv.IsValid() {
+		return &Table{}, nil
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		return mapTable(rv), nil
+	case reflect.Slice, reflect.Array:
+		rows := make([]reflect.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			rows = append(rows, rv.Index(i))
+		}
+		return rowsTable(indirectType(rv.Type().Elem()), rows, opts)
+	case reflect.Struct:
+		if index, ok := rowsField(rv.Type()); ok {
+			return tableOf(rv.FieldByIndex(index), opts)
+		}
+		return rowsTable(rv.Type(), []reflect.Value{rv}, opts)
+	}
+	return nil, fmt.Errorf("%w for %s", ErrNoColumns, rv.Type())
+}
+
+// rowsTable - one row per value, the values are all of type t
+func rowsTable(t reflect.Type, values []reflect.Value, opts Options) (*Table, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w for %s", ErrNoColumns, t)
+	}
+	cols := make([]column, 0)
+	for _, col := range columnsOf(t, nil) {
+		if col.wide && !opts.Wide {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("%w for %s", ErrNoColumns, t)
+	}
+
+	rows := make([][]string, 0, len(values))
+	for _, v := range values {
+		v = indirect(v)
+		row := make([]string, 0, len(cols))
+		for _, col := range cols {
+			row = append(row, formatCell(fieldByIndex(v, col.index)))
+		}
+		rows = append(rows, row)
+	}
+
+	// drop the omitempty columns with nothing in them
+	keep := make([]int, 0, len(cols))
+	for i, col := range cols {
+		if col.omitempty && emptyColumn(rows, i) {
+			continue
+		}
+		keep = append(keep, i)
+	}
+	table := &Table{Header: make([]string, 0, len(keep))}
+	for _, i := range keep {
+		table.Header = append(table.Header, cols[i].header)
+	}
+	for _, row := range rows {
+		kept := make([]string, 0, len(keep))
+		for _, i := range keep {
+			kept = append(kept, row[i])
+		}
+		table.Rows = append(table.Rows, kept)
+	}
+	return table, nil
+}
+
+// mapTable - a KEY/VALUE row per entry, sorted by key
+func mapTable(rv reflect.Value) *Table {
+	table := &Table{Header: []string{"KEY", "VALUE"}}
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return formatCell(keys[i]) < formatCell(keys[j])
+	})
+	for _, k := range keys {
+		table.Append(formatCell(k), formatCell(rv.MapIndex(k)))
+	}
+	return table
+}
+
+// columnsOf - the columns declared by t, index is the path to t
+func columnsOf(t reflect.Type, index []int) []column {
+	cols := make([]column, 0)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("table")
+		if !ok || tag == "-" || len(f.PkgPath) > 0 {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		parts := strings.Split(tag, ",")
+		col := column{header: parts[0], index: fieldIndex}
+		inline, rows := false, false
+		for _, opt := range parts[1:] {
+			switch {
+			case opt == "wide":
+				col.wide = true
+			case opt == "omitempty":
+				col.omitempty = true
+			case opt == "inline":
+				inline = true
+			case opt == "rows":
+				rows = true
+			case strings.HasPrefix(opt, "order="):
+				col.order, _ = strconv.Atoi(strings.TrimPrefix(opt, "order="))
+			}
+		}
+		switch {
+		case rows:
+			continue
+		case inline:
+			if ft := indirectType(f.Type); ft.Kind() == reflect.Struct {
+				cols = append(cols, columnsOf(ft, fieldIndex)...)
+			}
+			continue
+		case len(col.header) == 0:
+			col.header = strings.ToUpper(f.Name)
+		}
+		cols = append(cols, col)
+	}
+	sort.SliceStable(cols, func(i, j int) bool { return cols[i].order < cols[j].order })
+	return cols
+}
+
+// hasWideColumns - true when the table of v has columns that are only
+// shown by -o wide
+func hasWideColumns(v interface{}, opts Options) bool {
+	if _, ok := v.(Texter); ok {
+		return false
+	}
+	if _, ok := v.(Tabler); ok {
+		opts.Wide = false
+		narrow, err := TableFor(v, opts)
+		if err != nil {
+			return false
+		}
+		opts.Wide = true
+		wide, err := TableFor(v, opts)
+		return err == nil && len(wide.Header) > len(narrow.Header)
+	}
+	t := indirectType(reflect.TypeOf(v))
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			t = indirectType(t.Elem())
+			continue
+		case reflect.Struct:
+			if index, ok := rowsField(t); ok {
+				t = indirectType(t.FieldByIndex(index).Type)
+				continue
+			}
+			for _, col := range columnsOf(t, nil) {
+				if col.wide {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// rowsField - the index of the field tagged `table:",rows"`
+func rowsField(t reflect.Type) ([]int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("table")
+		for _, opt := range strings.Split(tag, ",")[1:] {
+			if opt == "rows" {
+				return []int{i}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// fieldByIndex - like reflect.Value.FieldByIndex, but an invalid value
+// for a nil pointer on the way
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		v = indirect(v)
+		if !v.IsValid() {
+			return v
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+func emptyColumn(rows [][]string, i int) bool {
+	for _, row := range rows {
+		if len(row[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// formatCell - the text of a cell, times are shown in local time, slices
+// are joined with spaces and maps are compact JSON
+func formatCell(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	switch x := v.Interface().(type) {
+	case Cell:
+		return x.Cell()
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		return x.Local().Format(time.RFC3339)
+	case fmt.Stringer:
+		return x.String()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if raw, err := json.Marshal(v.Interface()); err == nil {
+			return string(raw)
+		}
+	case reflect.Slice, reflect.Array:
+		cells := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cells = append(cells, formatCell(v.Index(i)))
+		}
+		return strings.Join(cells, " ")
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// indirect - follows pointers and interfaces, invalid for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,240 @@
+package printers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// relaxedJSONPath - accepts .name and name as well as {.name}, like kubectl
+func relaxedJSONPath(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.Contains(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	return fmt.Sprintf("{%s}", expr)
+}
+
+func parseJSONPath(expr string) (*jsonpath.JSONPath, error) {
+	if len(strings.TrimSpace(expr)) == 0 {
+		return nil, errors.New("a template is required, ie: jsonpath='{.name}'")
+	}
+	j := jsonpath.New("jsonpath").AllowMissingKeys(true)
+	if err := j.Parse(relaxedJSONPath(expr)); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func checkJSONPath(arg string) error {
+	_, err := parseJSONPath(arg)
+	return err
+}
+
+// printJSONPath - the JSONPath template executed against the JSON of v
+func printJSONPath(v interface{}, opts Options) (string, error) {
+	j, err := parseJSONPath(opts.Arg)
+	if err != nil {
+		return "", err
+	}
+	data, err := generic(v)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := j.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func parseGoTemplate(text string) (*template.Template, error) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return nil, errors.New("a template is required, ie: go-template='{{.name}}'")
+	}
+	return template.New("output").Parse(text)
+}
+
+func checkGoTemplate(arg string) error {
+	_, err := parseGoTemplate(arg)
+	return err
+}
+
+func checkGoTemplateFile(arg string) error {
+	text, err := ioutil.ReadFile(arg)
+	if err != nil {
+		return err
+	}
+	return checkGoTemplate(string(text))
+}
+
+// printGoTemplate - the Go template executed against the JSON of v, so
+// fields are named as they are in -o json
+func printGoTemplate(v interface{}, opts Options) (string, error) {
+	t, err := parseGoTemplate(opts.Arg)
+	if err != nil {
+		return "", err
+	}
+	data, err := generic(v)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func printGoTemplateFile(v interface{}, opts Options) (string, error) {
+	text, err := ioutil.ReadFile(opts.Arg)
+	if err != nil {
+		return "", err
+	}
+	opts.Arg = string(text)
+	return printGoTemplate(v, opts)
+}
+
+// customColumn - a HEADER:.json.path pair of -o custom-columns
+type customColumn struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// customColumnHeader - the HEADER: a column of -o custom-columns starts with
+var customColumnHeader = regexp.MustCompile(`^[^:,{}\[\]'"]+:`)
+
+// splitCustomColumns - the columns of spec, split at the commas that start
+// the next HEADER: and aren't within the {} or [] of a path
+func splitCustomColumns(spec string) []string {
+	parts := make([]string, 0)
+	depth, quote, start := 0, rune(0), 0
+	for i, r := range spec {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case depth > 0 && (r == '\'' || r == '"'):
+			quote = r
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			if depth > 0 {
+				depth--
+			}
+		case r == ',' && depth == 0 && customColumnHeader.MatchString(spec[i+1:]):
+			parts = append(parts, spec[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, spec[start:])
+}
+
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	if len(strings.TrimSpace(spec)) == 0 {
+		return nil, errors.New("columns are required, ie: custom-columns=NAME:.name,STATUS:.status")
+	}
+	cols := make([]customColumn, 0)
+	for _, part := range splitCustomColumns(spec) {
+		pair := strings.SplitN(part, ":", 2)
+		if len(pair) != 2 || len(pair[0]) == 0 || len(pair[1]) == 0 {
+			return nil, fmt.Errorf("expected HEADER:.json.path, got '%s'", part)
+		}
+		j, err := parseJSONPath(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", pair[0], err)
+		}
+		cols = append(cols, customColumn{header: pair[0], path: j})
+	}
+	return cols, nil
+}
+
+func checkCustomColumns(arg string) error {
+	_, err := parseCustomColumns(arg)
+	return err
+}
+
+// printCustomColumns - a table with a row per row of the text output, the
+// column paths are relative to the JSON of the row
+func printCustomColumns(v interface{}, opts Options) (string, error) {
+	cols, err := parseCustomColumns(opts.Arg)
+	if err != nil {
+		return "", err
+	}
+	table := &Table{}
+	for _, col := range cols {
+		table.Header = append(table.Header, col.header)
+	}
+	for _, item := range rowItems(v) {
+		data, err := generic(item)
+		if err != nil {
+			return "", err
+		}
+		row := make([]string, 0, len(cols))
+		for _, col := range cols {
+			results, err := col.path.FindResults(data)
+			if err != nil {
+				return "", err
+			}
+			values := make([]string, 0)
+			for _, set := range results {
+				for _, value := range set {
+					values = append(values, fmt.Sprintf("%v", value.Interface()))
+				}
+			}
+			if len(values) == 0 {
+				values = append(values, "<none>")
+			}
+			row = append(row, strings.Join(values, ","))
+		}
+		table.Append(row...)
+	}
+	return table.Render(opts.NoHeaders), nil
+}
+
+// rowItems - the values that are the rows of the table of v, map entries
+// are {"key": k, "value": v}
+func rowItems(v interface{}) []interface{} {
+	rv := indirect(reflect.ValueOf(structured(v)))
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.Kind() == reflect.Struct {
+		index, ok := rowsField(rv.Type())
+		if !ok {
+			return []interface{}{rv.Interface()}
+		}
+		rv = indirect(rv.FieldByIndex(index))
+	}
+	items := make([]interface{}, 0)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i).Interface())
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return formatCell(keys[i]) < formatCell(keys[j])
+		})
+		for _, k := range keys {
+			items = append(items, map[string]interface{}{"key": k.Interface(), "value": rv.MapIndex(k).Interface()})
+		}
+	case reflect.Invalid:
+	default:
+		items = append(items, rv.Interface())
+	}
+	return items
+}